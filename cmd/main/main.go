@@ -1,15 +1,23 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/auth"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/config"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/database"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/handler"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/indexer"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/logging"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/metrics"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/router"
 
@@ -32,9 +40,20 @@ import (
 
 // @schemes http https
 
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Session token dari POST /auth/verify, format "Bearer <token>"
+
+// @tag.name Auth
+// @tag.description Sign-In With Ethereum (EIP-4361) dan session token
+
 // @tag.name Projects
 // @tag.description Endpoints untuk mengelola proyek crowdfunding
 
+// @tag.name Investments
+// @tag.description Ledger investasi per project (nominal, token, chain, transaksi)
+
 // @tag.name User Profiles
 // @tag.description Endpoints untuk mengelola profil pengguna
 
@@ -48,50 +67,122 @@ func main() {
 	// Load konfigurasi
 	cfg := config.LoadConfig()
 
+	// Logger slog (LOG_LEVEL, LOG_FORMAT) menjadi default untuk seluruh aplikasi
+	if _, err := logging.Setup(cfg.LogLevel, cfg.LogFormat); err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid logging configuration:", err)
+		os.Exit(2)
+	}
+
+	// Subcommand: api migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(cfg, os.Args[2:])
+		return
+	}
+
+	// Sign-In With Ethereum tidak boleh berjalan tanpa domain, secret dan chain yang eksplisit
+	if err := cfg.ValidateAuth(); err != nil {
+		fatal("invalid auth configuration", err)
+	}
+	if err := cfg.ValidateIndexer(); err != nil {
+		fatal("invalid indexer configuration", err)
+	}
+
 	// Inisialisasi database
 	if err := database.InitDatabase(cfg); err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		fatal("failed to initialize database", err)
 	}
 
 	db := database.GetDB()
 
+	// Metrik Prometheus: durasi query GORM dan statistik pool koneksi
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		fatal("failed to register GORM metrics", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("failed to get database handle", err)
+	}
+	if err := metrics.RegisterDB(sqlDB, cfg.DBName); err != nil {
+		fatal("failed to register database pool metrics", err)
+	}
+
 	// Inisialisasi repositories
 	projectRepo := repository.NewProjectRepository(db)
 	profileRepo := repository.NewUserProfileRepository(db)
 	commentRepo := repository.NewCommentRepository(db)
+	nonceRepo := repository.NewAuthNonceRepository(db)
+	investmentRepo := repository.NewInvestmentRepository(db)
+	linkRepo := repository.NewExternalLinkRepository(db)
+
+	// Inisialisasi session token manager
+	tokens := auth.NewTokenManager([]byte(cfg.AuthSecret), cfg.AuthSessionTTL)
+	admins := auth.NewAdminSet(cfg.AdminWallets)
+
 	// Inisialisasi handlers
+	authHandler := handler.NewAuthHandler(nonceRepo, tokens, auth.SIWEPolicy{
+		Domain:   cfg.AuthDomain,
+		ChainIDs: cfg.AuthChainIDs,
+	}, cfg.AuthNonceTTL)
 	projectHandler := handler.NewProjectHandler(projectRepo)
+	investmentHandler := handler.NewInvestmentHandler(investmentRepo, projectRepo)
 	profileHandler := handler.NewUserProfileHandler(profileRepo)
-	commentHandler := handler.NewCommentHandler(commentRepo, projectRepo)
+	commentHandler := handler.NewCommentHandler(commentRepo, projectRepo, admins)
+	linkHandler := handler.NewExternalLinkHandler(linkRepo, projectRepo)
+
+	// Jalankan indexer on-chain di background jika diaktifkan
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if cfg.IndexerEnabled {
+		ix := indexer.New(indexer.Config{
+			ContractAddress: cfg.ContractAddress,
+			ChainID:         cfg.IndexerChainID,
+			StartBlock:      *cfg.IndexerStartBlock,
+			Confirmations:   cfg.IndexerConfirmations,
+			BlockRange:      cfg.IndexerBlockRange,
+			PollInterval:    cfg.IndexerPollInterval,
+		}, indexer.NewRPCClient(cfg.EthRPCURL, nil), indexer.NewRepositoryStore(repository.NewIndexerCheckpointRepository(db), projectRepo))
+
+		go func() {
+			if err := ix.Run(ctx); err != nil {
+				slog.Error("indexer stopped", "error", err)
+			}
+		}()
+	}
+
+	// Pindahkan project live yang sudah berakhir ke funded/failed secara berkala
+	if cfg.ProjectSettleInterval > 0 {
+		go runProjectSettler(ctx, projectRepo, cfg.ProjectSettleInterval)
+	}
+
+	// Purge permanen project yang sudah melewati masa retensi soft delete
+	if cfg.ProjectPurgeInterval > 0 {
+		go runProjectPurger(ctx, projectRepo, cfg.ProjectPurgeInterval, cfg.ProjectPurgeRetention)
+	}
 
 	// Inisialisasi Fiber app
 	app := fiber.New(fiber.Config{
-		AppName: "Web3 Crowdfunding API v1.0",
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			return c.Status(code).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		},
+		AppName:      "Web3 Crowdfunding API v1.0",
+		ErrorHandler: problem.ErrorHandler,
 	})
 
 	// Middleware
-	app.Use(recover.New()) // Recover from panics
-	app.Use(logger.New(logger.Config{
-		Format:     "${time} | ${status} | ${latency} | ${ip} | ${method} | ${path}\n",
-		TimeFormat: "2006-01-02 15:04:05",
-	}))
+	app.Use(recover.New())        // Recover from panics
+	app.Use(requestid.New())      // X-Request-ID untuk korelasi log dan response error
+	app.Use(metrics.Middleware()) // Metrik HTTP Prometheus per route (membaca status akhir dari logging)
+	app.Use(logging.Middleware()) // Request ID ke context + access log JSON; satu-satunya yang menjalankan ErrorHandler
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,PATCH,DELETE,OPTIONS",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-Request-ID, If-Match, If-None-Match",
+		ExposeHeaders: "X-Request-ID, ETag",
 	}))
 
 	// Setup routes
-	router.SetupRoutes(app, projectHandler, profileHandler, commentHandler)
+	router.SetupRoutes(app, tokens, admins, projectRepo, authHandler, projectHandler, investmentHandler, profileHandler, commentHandler, linkHandler)
+
+	// Metrik Prometheus (di luar /api/v1, tanpa auth)
+	app.Get("/metrics", metrics.Handler())
 
 	// Root endpoint
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -100,16 +191,24 @@ func main() {
 			"version": "1.0",
 			"endpoints": fiber.Map{
 				"health":   "/api/v1/health",
+				"auth":     "/api/v1/auth",
 				"projects": "/api/v1/projects",
 				"profiles": "/api/v1/profiles",
+				"metrics":  "/metrics",
 			},
 		})
 	})
 
 	// Start server
 	port := cfg.ServerPort
-	log.Printf("Server starting on port %s...", port)
+	slog.Info("server starting", "port", port)
 	if err := app.Listen(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		fatal("failed to start server", err)
 	}
 }
+
+// fatal mencatat error lalu menghentikan proses, pengganti log.Fatal untuk logger slog
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
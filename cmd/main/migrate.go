@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/config"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/database"
+)
+
+const migrateUsage = `Usage: api migrate <command>
+
+Commands:
+  up          Apply all pending migrations
+  down [n]    Roll back the last n applied migrations (default 1)
+  status      Show applied and pending migrations`
+
+// runMigrate menjalankan subcommand "migrate up|down|status"
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	if err := database.Connect(cfg); err != nil {
+		fatal("failed to connect to database", err)
+	}
+
+	migrator, err := database.NewMigrator(database.GetDB())
+	if err != nil {
+		fatal("failed to load migrations", err)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			fmt.Printf("applied  %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fatal("migration failed", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				fatal("invalid number of steps", fmt.Errorf("%q", args[1]))
+			}
+		}
+		reverted, err := migrator.Down(steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fatal("rollback failed", err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("no applied migrations")
+		}
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			fatal("failed to read migration status", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-40s %s\n", s.Version, s.Name, state)
+		}
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// runProjectPurger menghapus permanen project yang di-soft delete lebih lama dari retention,
+// setiap interval sampai ctx dibatalkan
+func runProjectPurger(ctx context.Context, projects repository.ProjectStore, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := projects.PurgeDeleted(time.Now().Add(-retention))
+		if err != nil {
+			slog.ErrorContext(ctx, "project purger failed", "error", err)
+		} else if purged > 0 {
+			slog.InfoContext(ctx, "project purger purged deleted projects", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// runProjectSettler menjalankan ProjectRepository.SettleEnded setiap interval sampai ctx dibatalkan
+func runProjectSettler(ctx context.Context, projects repository.ProjectStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		settled, err := projects.SettleEnded(time.Now())
+		if err != nil {
+			slog.ErrorContext(ctx, "project settler failed", "error", err)
+		} else if settled > 0 {
+			slog.InfoContext(ctx, "project settler moved projects to funded/failed", "count", settled)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -23,6 +23,121 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/me": {
+            "get": {
+                "description": "Return the wallet address attached to the bearer session token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Current session",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AuthMeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/nonce": {
+            "get": {
+                "description": "Issue a single-use nonce to be embedded in an EIP-4361 (Sign-In With Ethereum) message",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get SIWE nonce",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NonceResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify": {
+            "post": {
+                "description": "Verify a signed EIP-4361 message (personal_sign) and issue a session token for the recovered wallet.\nThe message domain and URI host must equal AUTH_DOMAIN, the chain ID must be listed in AUTH_CHAIN_IDS, and Issued At must not be in the future",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Verify SIWE signature",
+                "parameters": [
+                    {
+                        "description": "Signed SIWE message",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SIWEVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/profiles/{walletAddress}": {
             "get": {
                 "description": "Get user profile by wallet address",
@@ -73,7 +188,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Create a new profile or update existing one (Upsert operation)",
+                "description": "Create a new profile or update existing one (Upsert operation). Only the owner of the wallet may do this.\nkyc_status is managed by the server: new profiles start as \"unverified\" and updates keep the stored value",
                 "consumes": [
                     "application/json"
                 ],
@@ -98,7 +213,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserProfile"
+                            "$ref": "#/definitions/model.UserProfileUpsert"
                         }
                     }
                 ],
@@ -115,6 +230,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -127,12 +254,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/projects": {
             "get": {
-                "description": "Retrieve list of all crowdfunding projects",
+                "description": "Retrieve a page of crowdfunding projects. Uses cursor-based pagination that is stable on (sort key, id).\ninvestor_count is computed live and can change between requests, so that sort returns only the top page: next_cursor is always empty and cursor is rejected",
                 "consumes": [
                     "application/json"
                 ],
@@ -143,14 +275,83 @@ const docTemplate = `{
                     "Projects"
                 ],
                 "summary": "Get all projects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "-created_at",
+                            "updated_at",
+                            "-updated_at",
+                            "title",
+                            "-title",
+                            "investor_count",
+                            "-investor_count"
+                        ],
+                        "type": "string",
+                        "description": "Sort key, prefix with - for descending (default -created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by genre (case-insensitive)",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by game type (case-insensitive)",
+                        "name": "game_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by creator wallet address",
+                        "name": "creator_wallet_address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by developer name (partial match)",
+                        "name": "developer_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated statuses (default live,funded,failed). draft and review require authentication and only include projects the wallet created or co-owns",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ProjectSwagger"
-                            }
+                            "$ref": "#/definitions/model.ProjectListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
@@ -162,7 +363,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new crowdfunding project entry. UUID v7 will be auto-generated",
+                "description": "Create a new crowdfunding project entry in draft status. The creator is the authenticated wallet. goal_amount must be positive and end_date in the future when provided",
                 "consumes": [
                     "application/json"
                 ],
@@ -197,18 +398,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/projects/{id}": {
+        "/projects/search": {
             "get": {
-                "description": "Get detailed information about a specific project",
+                "description": "Full-text search over title, description, developer name and genre of live, funded and failed projects, ranked by relevance with highlighted snippets",
                 "consumes": [
                     "application/json"
                 ],
@@ -218,21 +436,43 @@ const docTemplate = `{
                 "tags": [
                     "Projects"
                 ],
-                "summary": "Get project by ID",
+                "summary": "Search projects",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Search keywords (websearch syntax: quoted phrases, OR, -exclude)",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Stemming language; both are used when omitted",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ProjectSwagger"
+                            "$ref": "#/definitions/model.ProjectSearchResponse"
                         }
                     },
                     "400": {
@@ -241,12 +481,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -254,9 +488,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update project information",
+            }
+        },
+        "/projects/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific project. Draft and review projects are only visible to their owners. The weak ETag header covers the project version and its funding data; send it back in If-None-Match to get 304 when nothing changed. If-Match on PATCH/PUT takes the strong version ETag (\"\u003cversion\u003e\") instead",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,23 +502,20 @@ const docTemplate = `{
                 "tags": [
                     "Projects"
                 ],
-                "summary": "Update project",
+                "summary": "Get project by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Fields to update",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ProjectPatch"
-                        }
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -290,8 +523,17 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.ProjectSwagger"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Weak ETag of the project version and funding data"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Not modified"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -311,11 +553,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/projects/{id}/comments": {
-            "get": {
-                "description": "Get all comments for a specific project",
+            },
+            "put": {
+                "description": "Fully replace an existing project including links (PUT). Links provided will replace existing links. The body is validated like POST /projects, with the same status-dependent funding rules as PATCH: outside draft goal_amount and end_date cannot be cleared, and an unchanged end_date may be in the past. Unknown IDs return 404 (create projects with POST). creator_wallet_address, created_at, status and investors are owned by the server and kept; creator_wallet_address may be omitted or repeated but not changed. If-Match must carry the current ETag; a stale ETag returns 412",
                 "consumes": [
                     "application/json"
                 ],
@@ -323,25 +563,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Projects"
                 ],
-                "summary": "Get project comments",
+                "summary": "Replace project",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current project version as a strong ETag (the version field in double quotes)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Full project data (links included)",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProjectCreate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Comment"
+                            "$ref": "#/definitions/model.ProjectSwagger"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New project version"
                             }
                         }
                     },
@@ -351,22 +610,51 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             },
-            "post": {
-                "description": "Add a new comment to a project. Supports nested comments via parent_comment_id",
+            "delete": {
+                "description": "Soft-delete a project. It disappears from every endpoint but can be restored until it is purged after the retention window",
                 "consumes": [
                     "application/json"
                 ],
@@ -374,32 +662,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Projects"
                 ],
-                "summary": "Create comment",
+                "summary": "Delete project",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Comment data",
-                        "name": "comment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CommentCreate"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Comment"
+                            "$ref": "#/definitions/model.GenericMessage"
                         }
                     },
                     "400": {
@@ -408,8 +687,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -420,14 +711,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/projects/{id}/investors": {
-            "get": {
-                "description": "Get all investor wallet addresses for a project",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Partially update project information with JSON Merge Patch (RFC 7396). Omitted fields are left unchanged, null clears a field (goal_amount and end_date only while the project is a draft), and links replaces the whole list. Unknown fields are rejected. Returns the reloaded project with its links. If-Match must carry the current ETag; a stale ETag returns 412",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
@@ -435,21 +730,43 @@ const docTemplate = `{
                 "tags": [
                     "Projects"
                 ],
-                "summary": "Get project investors",
+                "summary": "Update project",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current project version as a strong ETag (the version field in double quotes)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProjectPatch"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.InvestorsResponse"
+                            "$ref": "#/definitions/model.ProjectSwagger"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New project version"
+                            }
                         }
                     },
                     "400": {
@@ -458,22 +775,59 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/archive": {
             "post": {
-                "description": "Add an investor's wallet address to a project",
+                "description": "Archive a draft, review, funded or failed project. Live projects cannot be archived until they end",
                 "consumes": [
                     "application/json"
                 ],
@@ -483,30 +837,21 @@ const docTemplate = `{
                 "tags": [
                     "Projects"
                 ],
-                "summary": "Add investor to project",
+                "summary": "Archive project",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Investor wallet address",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.AddInvestorRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GenericMessage"
+                            "$ref": "#/definitions/model.ProjectSwagger"
                         }
                     },
                     "400": {
@@ -515,6 +860,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -524,7 +881,7 @@ const docTemplate = `{
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "$ref": "#/definitions/model.StatusTransitionError"
                         }
                     },
                     "500": {
@@ -533,12 +890,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/projects/{id}/investors/{walletAddress}": {
-            "delete": {
-                "description": "Remove an investor's wallet address from a project",
+        "/projects/{id}/comments": {
+            "get": {
+                "description": "Get all comments for a specific project as a flat list (newest first), or as a tree with format=tree.\nComments hidden by a moderator are only included for the project creator and admins.\nIn tree mode, pass next_cursor or a comment's replies_cursor as cursor to load more top-level comments or replies",
                 "consumes": [
                     "application/json"
                 ],
@@ -546,30 +908,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Comments"
                 ],
-                "summary": "Remove investor from project",
+                "summary": "Get project comments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
+                        "enum": [
+                            "flat",
+                            "tree"
+                        ],
                         "type": "string",
-                        "description": "Investor wallet address",
-                        "name": "walletAddress",
-                        "in": "path",
-                        "required": true
+                        "description": "Response format (default flat)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "new",
+                            "top"
+                        ],
+                        "type": "string",
+                        "description": "Flat: new (default) or top (highest reaction score first)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tree: number of top-level comments, or replies when cursor is a replies_cursor (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tree: replies included per comment at each level (default 3, max 50)",
+                        "name": "replies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tree: number of levels to include (default and max 5)",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tree: next_cursor or replies_cursor from a previous response",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GenericMessage"
+                            "$ref": "#/definitions/model.CommentTreeResponse"
                         }
                     },
                     "400": {
@@ -591,11 +990,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/projects/{id}/links": {
-            "get": {
-                "description": "Get all external links for a specific project",
+            },
+            "post": {
+                "description": "Add a new comment to a project. Supports nested comments via parent_comment_id, up to 5 levels deep.\nauthor_wallet_address must be the authenticated wallet, and the author must sign (personal_sign) the text\n\"Web3 Crowdfunding Comment\\nProject ID: \u003cid\u003e\\nParent ID: \u003cparent id or none\u003e\\nContent:\\n\u003ccontent\u003e\"",
                 "consumes": [
                     "application/json"
                 ],
@@ -603,26 +1000,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "External Links"
+                    "Comments"
                 ],
-                "summary": "Get project external links",
+                "summary": "Create comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Comment data",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentCreate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ExternalLink"
-                            }
+                            "$ref": "#/definitions/model.Comment"
                         }
                     },
                     "400": {
@@ -631,6 +1034,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -643,10 +1058,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "post": {
-                "description": "Add a new external link to a project (e.g., Instagram, Twitter, Website)",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/comments/{commentId}": {
+            "delete": {
+                "description": "Delete a comment. Only the author (authenticated wallet) may delete. Comments that have replies are kept as a \"[deleted]\" placeholder so threads stay intact.\nReturns 404 when the project is deleted or is a draft/review project the author can no longer see",
                 "consumes": [
                     "application/json"
                 ],
@@ -654,32 +1076,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "External Links"
+                    "Comments"
                 ],
-                "summary": "Create external link",
+                "summary": "Delete comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "External link data",
-                        "name": "link",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ExternalLinkCreate"
-                        }
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ExternalLink"
+                            "$ref": "#/definitions/model.GenericMessage"
                         }
                     },
                     "400": {
@@ -688,6 +1108,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -700,12 +1132,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/projects/{id}/links/{linkId}": {
-            "put": {
-                "description": "Update an external link",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Replace the content of a comment. Only the author (authenticated wallet) may edit, and the new content must be signed\nwith the same message format as CreateComment. The previous content is kept in the comment's history.\nReturns 404 when the project is deleted or is a draft/review project the author can no longer see",
                 "consumes": [
                     "application/json"
                 ],
@@ -713,31 +1148,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "External Links"
+                    "Comments"
                 ],
-                "summary": "Update external link",
+                "summary": "Edit comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Link ID (UUID v7)",
-                        "name": "linkId",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "External link data",
-                        "name": "link",
+                        "description": "New content and signature",
+                        "name": "comment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ExternalLinkCreate"
+                            "$ref": "#/definitions/model.CommentUpdate"
                         }
                     }
                 ],
@@ -745,7 +1180,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ExternalLink"
+                            "$ref": "#/definitions/model.Comment"
                         }
                     },
                     "400": {
@@ -754,22 +1189,47 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "delete": {
-                "description": "Delete an external link from a project",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/comments/{commentId}/history": {
+            "get": {
+                "description": "List the previous revisions of an edited comment, oldest first.\nComments hidden by a moderator return 404 unless the caller is the project creator or an admin",
                 "consumes": [
                     "application/json"
                 ],
@@ -777,21 +1237,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "External Links"
+                    "Comments"
                 ],
-                "summary": "Delete external link",
+                "summary": "Get comment edit history",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (UUID v7)",
+                        "description": "Project ID (numeric timestamped ID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Link ID (UUID v7)",
-                        "name": "linkId",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
                         "in": "path",
                         "required": true
                     }
@@ -800,7 +1260,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GenericMessage"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CommentRevision"
+                            }
                         }
                     },
                     "400": {
@@ -823,170 +1286,2668 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AddInvestorRequest": {
-            "type": "object",
-            "properties": {
-                "wallet_address": {
-                    "type": "string",
-                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
-                }
-            }
         },
-        "model.Comment": {
-            "type": "object",
-            "properties": {
+        "/projects/{id}/comments/{commentId}/reactions/{type}": {
+            "put": {
+                "description": "Add a reaction from the authenticated wallet. Each wallet can react once per type; repeating a reaction is a no-op.\nComments hidden by a moderator cannot be reacted to (404)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "React to comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "upvote",
+                            "like",
+                            "heart",
+                            "fire",
+                            "laugh"
+                        ],
+                        "type": "string",
+                        "description": "Reaction type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentReactionsResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentReactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove a reaction of the authenticated wallet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Remove comment reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "upvote",
+                            "like",
+                            "heart",
+                            "fire",
+                            "laugh"
+                        ],
+                        "type": "string",
+                        "description": "Reaction type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentReactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/comments/{commentId}/reports": {
+            "post": {
+                "description": "Report a comment to the project's moderators with a reason. A wallet can have one open report per comment.\nComments hidden by a moderator cannot be reported (404)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Report comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report reason",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentReportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/comments/{commentId}/verification": {
+            "get": {
+                "description": "Rebuild the signed message of a stored comment and re-verify its author signature.\nComments hidden by a moderator return 404 unless the caller is the project creator or an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Verify comment signature",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentSignatureVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/investments": {
+            "get": {
+                "description": "List the investment ledger of a project, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Investments"
+                ],
+                "summary": "Get project investments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only investments from this wallet",
+                        "name": "wallet_address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvestmentListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Record a manual (off-chain) investment in the project ledger. Only the project creator or co-owners may record investments manually.\nOn-chain fields (chain_id, tx_hash, log_index, block_number) are rejected; they are recorded by the indexer only. Manual entries are listed\nwith source \"manual\" and do not count toward funding_progress or the funded/failed settlement",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Investments"
+                ],
+                "summary": "Record investment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Investment data",
+                        "name": "investment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.InvestmentCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Investment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/investments/summary": {
+            "get": {
+                "description": "Aggregate the investment ledger of a project: distinct investors, number of investments and totals per chain/token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Investments"
+                ],
+                "summary": "Get project investment summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvestmentSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/investors": {
+            "get": {
+                "description": "Get all investor wallet addresses for a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get project investors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvestorsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an investor's wallet address to a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Add investor to project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Investor wallet address",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddInvestorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/investors/{walletAddress}": {
+            "delete": {
+                "description": "Remove an investor's manually recorded ledger entries from a project. Investors with on-chain investments (ingested by the indexer) cannot be removed and return 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Remove investor from project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Investor wallet address",
+                        "name": "walletAddress",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/links": {
+            "get": {
+                "description": "Get all external links for a specific project, ordered by position",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "External Links"
+                ],
+                "summary": "Get project external links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ExternalLink"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new external link to a project. url must be an absolute http(s) URL of at most 500 characters; type defaults to \"other\" and position defaults to the end of the list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "External Links"
+                ],
+                "summary": "Create external link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "External link data",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ExternalLinkCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExternalLink"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/links/{linkId}": {
+            "put": {
+                "description": "Update an external link. Only the fields present in the body are changed; the same validation as create applies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "External Links"
+                ],
+                "summary": "Update external link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link ID (numeric timestamped ID)",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "External link data",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ExternalLinkCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExternalLink"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete an external link from a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "External Links"
+                ],
+                "summary": "Delete external link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link ID (numeric timestamped ID)",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/moderation/comments": {
+            "get": {
+                "description": "List comments with open reports, most reported first. The project-scoped route is available to the project creator and admins;\n/moderation/comments lists every project and is admin-only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Get comment moderation queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ModerationQueueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/moderation/comments/{commentId}": {
+            "delete": {
+                "description": "Delete any comment of the project and resolve its open reports. Comments with replies are kept as a \"[deleted]\" placeholder.\nProject creator and admins only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Delete comment as moderator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/moderation/comments/{commentId}/dismiss": {
+            "post": {
+                "description": "Resolve a comment's open reports without hiding it. Project creator and admins only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Dismiss comment reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/moderation/comments/{commentId}/hide": {
+            "post": {
+                "description": "Hide a comment from everyone except moderators and resolve its open reports. Project creator and admins only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Hide comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/moderation/comments/{commentId}/unhide": {
+            "post": {
+                "description": "Make a hidden comment visible again. Project creator and admins only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Unhide comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID (numeric timestamped ID)",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/owners": {
+            "get": {
+                "description": "Get the creator and all co-owners allowed to modify a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get project owners",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OwnersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Allow another wallet to modify the project. Only the project creator may grant co-owners",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Grant co-owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Co-owner wallet address",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CoOwnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProjectCoOwner"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/owners/{walletAddress}": {
+            "delete": {
+                "description": "Revoke a co-owner's access to the project. Only the project creator may revoke co-owners",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Revoke co-owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Co-owner wallet address",
+                        "name": "walletAddress",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/publish": {
+            "post": {
+                "description": "Move a project in review to live so it appears in public listings. Only platform admins (ADMIN_WALLETS) may publish;\nproject owners get 403, so the review step is an actual approval. The end_date must still be in the future",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Publish project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProjectSwagger"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.StatusTransitionError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/restore": {
+            "post": {
+                "description": "Undo a soft delete. Only the creator, co-owners and admins may restore a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Restore deleted project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProjectSwagger"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/projects/{id}/submit": {
+            "post": {
+                "description": "Move a draft project to review. Requires goal_amount and a future end_date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Submit project for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (numeric timestamped ID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProjectSwagger"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.StatusTransitionError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "model.AddInvestorRequest": {
+            "type": "object",
+            "required": [
+                "wallet_address"
+            ],
+            "properties": {
+                "wallet_address": {
+                    "type": "string",
+                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
+                }
+            }
+        },
+        "model.AuthMeResponse": {
+            "type": "object",
+            "properties": {
+                "wallet_address": {
+                    "type": "string",
+                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
+                }
+            }
+        },
+        "model.CoOwnerRequest": {
+            "type": "object",
+            "required": [
+                "wallet_address"
+            ],
+            "properties": {
+                "wallet_address": {
+                    "type": "string",
+                    "example": "0x1234567890abcdef1234567890abcdef12345678"
+                }
+            }
+        },
+        "model.Comment": {
+            "type": "object",
+            "properties": {
+                "author_wallet_address": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "Terisi jika komentar dihapus tetapi dipertahankan sebagai placeholder",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "Terisi jika konten pernah diedit; revisi lama ada di comment_revisions",
+                    "type": "string"
+                },
+                "hidden_at": {
+                    "description": "Terisi jika disembunyikan moderator; hanya terlihat oleh moderator",
+                    "type": "string"
+                },
+                "hidden_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_comment_id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "reactions": {
+                    "description": "Jumlah reaksi per jenis",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "score": {
+                    "description": "Jumlah reaksi berbobot (lihat CommentReactionWeights)",
+                    "type": "integer"
+                },
+                "signature": {
+                    "description": "EIP-191 signature atas auth.CommentSigningMessage",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "viewer_reactions": {
+                    "description": "Reaksi wallet yang sedang login",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CommentCreate": {
+            "type": "object",
+            "required": [
+                "author_wallet_address",
+                "content",
+                "signature"
+            ],
+            "properties": {
+                "author_wallet_address": {
+                    "type": "string",
+                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "This project looks amazing!"
+                },
+                "parent_comment_id": {
+                    "type": "integer",
+                    "example": 1760500000000
+                },
+                "signature": {
+                    "description": "personal_sign over the comment signing message",
+                    "type": "string",
+                    "maxLength": 132,
+                    "example": "0x5d2f...1b"
+                }
+            }
+        },
+        "model.CommentNode": {
+            "type": "object",
+            "properties": {
+                "author_wallet_address": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "Terisi jika komentar dihapus tetapi dipertahankan sebagai placeholder",
+                    "type": "string"
+                },
+                "depth": {
+                    "description": "0 untuk komentar top-level",
+                    "type": "integer"
+                },
+                "edited_at": {
+                    "description": "Terisi jika konten pernah diedit; revisi lama ada di comment_revisions",
+                    "type": "string"
+                },
+                "hidden_at": {
+                    "description": "Terisi jika disembunyikan moderator; hanya terlihat oleh moderator",
+                    "type": "string"
+                },
+                "hidden_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_comment_id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "reactions": {
+                    "description": "Jumlah reaksi per jenis",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "replies": {
+                    "description": "Dibatasi per level, terlama lebih dulu",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommentNode"
+                    }
+                },
+                "replies_cursor": {
+                    "description": "Cursor untuk memuat balasan berikutnya",
+                    "type": "string"
+                },
+                "reply_count": {
+                    "description": "Jumlah seluruh balasan langsung, termasuk yang belum dimuat",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "Jumlah reaksi berbobot (lihat CommentReactionWeights)",
+                    "type": "integer"
+                },
+                "signature": {
+                    "description": "EIP-191 signature atas auth.CommentSigningMessage",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "viewer_reactions": {
+                    "description": "Reaksi wallet yang sedang login",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CommentReactionsResponse": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "integer",
+                    "example": 1760500000001
+                },
+                "reactions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "viewer_reactions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "upvote"
+                    ]
+                }
+            }
+        },
+        "model.CommentReport": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reporter_wallet_address": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "description": "CommentReportResolution*, nil selama laporan masih terbuka",
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommentReportRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Spam link to a phishing site"
+                }
+            }
+        },
+        "model.CommentRevision": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "Waktu revisi ini digantikan",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "signature": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommentSignatureVerification": {
+            "type": "object",
+            "properties": {
                 "author_wallet_address": {
+                    "type": "string",
+                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
+                },
+                "comment_id": {
+                    "type": "integer",
+                    "example": 1760500000001
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x5d2f...1b"
+                },
+                "signed_message": {
+                    "type": "string",
+                    "example": "Web3 Crowdfunding Comment\nProject ID: 1760500000000\nParent ID: none\nContent:\nThis project looks amazing!"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.CommentTreeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommentNode"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJkIjowLCJhIjp7InQiOiIyMDI1LTEwLTE1VDEwOjAwOjAwWiIsImlkIjoxfX0"
+                }
+            }
+        },
+        "model.CommentUpdate": {
+            "type": "object",
+            "required": [
+                "content",
+                "signature"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "This project looks amazing! (edited)"
+                },
+                "signature": {
+                    "type": "string",
+                    "maxLength": 132,
+                    "example": "0x5d2f...1b"
+                }
+            }
+        },
+        "model.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string",
+                    "example": "Project not found"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProblemFieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/projects/1"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f1c2a9e-5b7d-4e0a-9c1f-2d8e6b4a7c10"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "model.ExternalLink": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "e.g., \"Instagram\", \"Twitter\", \"Website\"",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Urutan tampilan, kecil lebih dulu",
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Salah satu ExternalLinkTypes",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "The actual URL (http/https saja)",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExternalLinkCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "url"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Instagram"
+                },
+                "position": {
+                    "description": "Default: akhir daftar",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "website",
+                        "twitter",
+                        "discord",
+                        "telegram",
+                        "steam",
+                        "epic",
+                        "itchio",
+                        "youtube",
+                        "twitch",
+                        "instagram",
+                        "tiktok",
+                        "facebook",
+                        "reddit",
+                        "github",
+                        "whitepaper",
+                        "other"
+                    ],
+                    "example": "instagram"
+                },
+                "url": {
+                    "description": "http/https saja, maks 500 karakter",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "https://instagram.com/mygame"
+                }
+            }
+        },
+        "model.FundingProgress": {
+            "type": "object",
+            "properties": {
+                "backer_count": {
+                    "description": "Jumlah wallet investor unik",
+                    "type": "integer"
+                },
+                "ended": {
+                    "type": "boolean"
+                },
+                "goal": {
+                    "description": "Sama dengan Project.GoalAmount",
                     "type": "string"
                 },
-                "content": {
-                    "type": "string"
+                "percent": {
+                    "description": "Raised / Goal * 100, nil jika project tidak punya target",
+                    "type": "number"
+                },
+                "raised": {
+                    "description": "Total bersih (investasi - refund) dalam mata uang project",
+                    "type": "string"
+                },
+                "time_remaining_seconds": {
+                    "description": "Sisa waktu hingga EndDate, nil jika tanpa deadline",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GenericMessage": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Investor added successfully"
+                }
+            }
+        },
+        "model.Investment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Satuan terkecil token (wei) dalam bentuk desimal",
+                    "type": "string"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "chain_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invested_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "InvestmentKindInvestment atau InvestmentKindRefund",
+                    "type": "string"
+                },
+                "log_index": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "InvestmentSourceManual atau InvestmentSourceIndexed",
+                    "type": "string"
+                },
+                "token_address": {
+                    "description": "Kosong untuk native coin (ETH)",
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "wallet_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.InvestmentCreateRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "wallet_address"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "maxLength": 78,
+                    "example": "1500000000000000000"
+                },
+                "invested_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "investment",
+                        "refund"
+                    ],
+                    "example": "investment"
+                },
+                "token_address": {
+                    "type": "string",
+                    "example": ""
+                },
+                "wallet_address": {
+                    "type": "string",
+                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
+                }
+            }
+        },
+        "model.InvestmentListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Investment"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
                 },
-                "created_at": {
-                    "type": "string"
+                "offset": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "id": {
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "model.InvestmentSummary": {
+            "type": "object",
+            "properties": {
+                "first_invested_at": {
                     "type": "string"
                 },
-                "parent_comment_id": {
+                "investment_count": {
+                    "type": "integer"
+                },
+                "investor_count": {
+                    "type": "integer"
+                },
+                "last_invested_at": {
                     "type": "string"
                 },
                 "project_id": {
-                    "type": "string"
+                    "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "refund_count": {
+                    "type": "integer"
+                },
+                "totals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InvestmentTotal"
+                    }
                 }
             }
         },
-        "model.CommentCreate": {
+        "model.InvestmentTotal": {
             "type": "object",
             "properties": {
-                "author_wallet_address": {
-                    "type": "string",
-                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
+                "amount": {
+                    "type": "string"
                 },
-                "content": {
-                    "type": "string",
-                    "example": "This project looks amazing!"
+                "chain_id": {
+                    "type": "integer"
                 },
-                "parent_comment_id": {
-                    "type": "string",
-                    "example": "0199fb01-b44b-7a26-9625-3be2baf2d905"
+                "token_address": {
+                    "type": "string"
                 }
             }
         },
-        "model.ErrorResponse": {
+        "model.InvestorsResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Resource not found"
+                "investors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"0x111...\"",
+                        " \"0x222...\"]"
+                    ]
                 }
             }
         },
-        "model.ExternalLink": {
+        "model.ModerationQueueResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReportedComment"
+                    }
                 },
-                "name": {
-                    "description": "e.g., \"Instagram\", \"Twitter\", \"Website\"",
-                    "type": "string"
+                "limit": {
+                    "type": "integer",
+                    "example": 20
                 },
-                "project_id": {
-                    "type": "string"
+                "offset": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "updated_at": {
+                "total": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "model.NonceResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
                     "type": "string"
                 },
-                "url": {
-                    "description": "The actual URL",
-                    "type": "string"
+                "nonce": {
+                    "type": "string",
+                    "example": "9f1c2a7b4e6d8f00a1b2c3d4e5f60718"
                 }
             }
         },
-        "model.ExternalLinkCreate": {
+        "model.OwnersResponse": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string",
-                    "example": "Instagram"
+                "co_owners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProjectCoOwner"
+                    }
                 },
-                "url": {
+                "creator_wallet_address": {
                     "type": "string",
-                    "example": "https://instagram.com/mygame"
+                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
                 }
             }
         },
-        "model.GenericMessage": {
+        "model.ProblemFieldError": {
             "type": "object",
             "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "links[0].url"
+                },
                 "message": {
                     "type": "string",
-                    "example": "Investor added successfully"
+                    "example": "url must use http or https"
                 }
             }
         },
-        "model.InvestorsResponse": {
+        "model.ProjectCoOwner": {
             "type": "object",
             "properties": {
-                "investors": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"0x111...\"",
-                        " \"0x222...\"]"
-                    ]
+                "created_at": {
+                    "type": "string"
+                },
+                "granted_by": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "wallet_address": {
+                    "type": "string"
                 }
             }
         },
         "model.ProjectCreate": {
             "type": "object",
+            "required": [
+                "title"
+            ],
             "properties": {
                 "cover_image_url": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "https://example.com/image.jpg"
                 },
                 "creator_wallet_address": {
                     "type": "string",
                     "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
                 },
+                "currency": {
+                    "type": "string",
+                    "maxLength": 10,
+                    "example": "ETH"
+                },
+                "currency_chain_id": {
+                    "description": "Default 1 (Ethereum mainnet); hanya ledger di chain ini yang dihitung ke funding",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "currency_token_address": {
+                    "description": "Kosong untuk native coin",
+                    "type": "string",
+                    "example": ""
+                },
                 "description": {
                     "type": "string",
                     "example": "This is an amazing Web3 game"
                 },
                 "developer_name": {
                     "type": "string",
+                    "maxLength": 100,
                     "example": "GameDev Studios"
                 },
+                "end_date": {
+                    "description": "Harus di masa depan",
+                    "type": "string"
+                },
                 "game_type": {
                     "type": "string",
+                    "maxLength": 10,
                     "example": "web3"
                 },
                 "genre": {
                     "type": "string",
+                    "maxLength": 50,
                     "example": "RPG"
                 },
+                "goal_amount": {
+                    "description": "Satuan terkecil token (wei), harus \u003e 0",
+                    "type": "string",
+                    "maxLength": 78,
+                    "example": "50000000000000000000"
+                },
+                "links": {
+                    "description": "Position mengikuti urutan array",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ExternalLinkCreate"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "My Awesome Game"
                 }
             }
         },
+        "model.ProjectListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProjectSwagger"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCIsImQiOnRydWUsInYiOiIyMDI1LTEwLTE1VDEwOjAwOjAwWiIsImlkIjoxfQ"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
         "model.ProjectPatch": {
             "type": "object",
             "properties": {
                 "cover_image_url": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "https://example.com/new-image.jpg"
                 },
                 "description": {
@@ -995,22 +3956,90 @@ const docTemplate = `{
                 },
                 "developer_name": {
                     "type": "string",
+                    "maxLength": 100,
                     "example": "New Studio Name"
                 },
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-12-31T23:59:59Z"
+                },
                 "game_type": {
                     "type": "string",
+                    "maxLength": 10,
                     "example": "web3"
                 },
                 "genre": {
                     "type": "string",
+                    "maxLength": 50,
                     "example": "Action RPG"
                 },
+                "goal_amount": {
+                    "type": "string",
+                    "maxLength": 78,
+                    "example": "75000000000000000000"
+                },
+                "links": {
+                    "description": "Replaces every link; null or [] removes them all",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ExternalLinkCreate"
+                    }
+                },
                 "title": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Updated Game Title"
                 }
             }
         },
+        "model.ProjectSearchHit": {
+            "type": "object",
+            "properties": {
+                "highlights": {
+                    "type": "object",
+                    "properties": {
+                        "description": {
+                            "type": "string",
+                            "example": "An amazing blockchain \u003cmark\u003eRPG\u003c/mark\u003e game"
+                        },
+                        "title": {
+                            "type": "string",
+                            "example": "Epic \u003cmark\u003eRPG\u003c/mark\u003e Game"
+                        }
+                    }
+                },
+                "project": {
+                    "$ref": "#/definitions/model.ProjectSwagger"
+                },
+                "rank": {
+                    "type": "number",
+                    "example": 0.6079271
+                }
+            }
+        },
+        "model.ProjectSearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProjectSearchHit"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
         "model.ProjectSwagger": {
             "type": "object",
             "properties": {
@@ -1025,6 +4054,19 @@ const docTemplate = `{
                     "type": "string",
                     "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
                 },
+                "currency": {
+                    "type": "string",
+                    "example": "ETH"
+                },
+                "currency_chain_id": {
+                    "description": "Only ledger entries on this chain count toward funding_progress",
+                    "type": "integer",
+                    "example": 1
+                },
+                "currency_token_address": {
+                    "type": "string",
+                    "example": ""
+                },
                 "description": {
                     "type": "string",
                     "example": "An amazing blockchain RPG game"
@@ -1033,6 +4075,12 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Epic Games Studio"
                 },
+                "end_date": {
+                    "type": "string"
+                },
+                "funding_progress": {
+                    "$ref": "#/definitions/model.FundingProgress"
+                },
                 "game_type": {
                     "type": "string",
                     "example": "web3"
@@ -1041,9 +4089,13 @@ const docTemplate = `{
                     "type": "string",
                     "example": "RPG"
                 },
-                "id": {
+                "goal_amount": {
                     "type": "string",
-                    "example": "0199fb01-ae3c-7c26-b70a-8f221585ccb4"
+                    "example": "50000000000000000000"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1760500000000
                 },
                 "investor_wallet_addresses": {
                     "type": "array",
@@ -1054,12 +4106,137 @@ const docTemplate = `{
                         "[\"0xabc...\"]"
                     ]
                 },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "review",
+                        "live",
+                        "funded",
+                        "failed",
+                        "archived"
+                    ],
+                    "example": "live"
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string",
                     "example": "Epic RPG Game"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "Same value as the ETag header",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.ReportedComment": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/model.Comment"
+                },
+                "last_reported_at": {
+                    "type": "string"
+                },
+                "report_count": {
+                    "type": "integer"
+                },
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommentReport"
+                    }
+                }
+            }
+        },
+        "model.SIWEVerifyRequest": {
+            "type": "object",
+            "required": [
+                "message",
+                "signature"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "localhost:3000 wants you to sign in with your Ethereum account:\n0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb\n\nSign in to Web3 Crowdfunding\n\nURI: http://localhost:3000\nVersion: 1\nChain ID: 1\nNonce: 9f1c2a7b4e6d8f00a1b2c3d4e5f60718\nIssued At: 2025-10-15T10:00:00Z"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x5d2f...1b"
+                }
+            }
+        },
+        "model.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJzdWIiOiIweDc0MmQzNUNj...Zq3w"
+                },
+                "wallet_address": {
+                    "type": "string",
+                    "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"
+                }
+            }
+        },
+        "model.StatusTransitionError": {
+            "type": "object",
+            "properties": {
+                "allowed_transitions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "funded",
+                        "failed"
+                    ]
+                },
+                "current_status": {
+                    "type": "string",
+                    "example": "live"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Project not found"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProblemFieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/projects/1"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f1c2a9e-5b7d-4e0a-9c1f-2d8e6b4a7c10"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
                 }
             }
         },
@@ -1088,13 +4265,53 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "model.UserProfileUpsert": {
+            "type": "object",
+            "required": [
+                "email",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "satoshi@example.com"
+                },
+                "profile_image_url": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "https://example.com/avatar.png"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "satoshi"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Session token dari POST /auth/verify, format \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     },
     "tags": [
+        {
+            "description": "Sign-In With Ethereum (EIP-4361) dan session token",
+            "name": "Auth"
+        },
         {
             "description": "Endpoints untuk mengelola proyek crowdfunding",
             "name": "Projects"
         },
+        {
+            "description": "Ledger investasi per project (nominal, token, chain, transaksi)",
+            "name": "Investments"
+        },
         {
             "description": "Endpoints untuk mengelola profil pengguna",
             "name": "User Profiles"
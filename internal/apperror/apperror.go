@@ -0,0 +1,102 @@
+// Package apperror berisi error domain yang dipakai bersama oleh repository, middleware
+// dan handler. Repository menerjemahkan error database ke jenis error di sini, handler
+// cukup mengembalikannya, dan ErrorHandler Fiber memetakannya ke response HTTP di satu tempat.
+package apperror
+
+import "errors"
+
+// Jenis error domain. Gunakan errors.Is(err, ErrNotFound) dan seterusnya untuk mengecek jenisnya.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation failed")
+	ErrForbidden  = errors.New("forbidden")
+
+	// ErrPreconditionFailed berarti versi data yang dikirim client (If-Match) sudah usang
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// FieldError adalah masalah pada satu field request
+type FieldError struct {
+	Field   string `json:"field"`   // nama field JSON, misalnya "links[0].url"
+	Message string `json:"message"` // pesan untuk client
+}
+
+// Error adalah error domain dengan pesan untuk client dan detail per field (opsional)
+type Error struct {
+	Kind    error                  // salah satu ErrNotFound, ErrConflict, ErrValidation, ErrForbidden, ErrPreconditionFailed
+	Message string                 // pesan yang aman ditampilkan ke client
+	Fields  []FieldError           // detail per field, urut sesuai ditemukan
+	Details map[string]interface{} // data tambahan untuk client, ditulis sebagai extension member problem
+	Err     error                  // penyebab asli (misalnya error driver), tidak ditampilkan ke client
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap membuat errors.Is/As bekerja baik untuk jenis error maupun penyebab aslinya
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+// WithField menambahkan detail untuk satu field dan mengembalikan error yang sama
+func (e *Error) WithField(field, message string) *Error {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+	return e
+}
+
+// With menambahkan data tambahan untuk client dan mengembalikan error yang sama
+func (e *Error) With(key string, value interface{}) *Error {
+	if e.Details == nil {
+		e.Details = map[string]interface{}{}
+	}
+	e.Details[key] = value
+	return e
+}
+
+// Wrap menyimpan penyebab asli error dan mengembalikan error yang sama
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// NotFound membuat error ErrNotFound
+func NotFound(message string) *Error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
+
+// Conflict membuat error ErrConflict
+func Conflict(message string) *Error {
+	return &Error{Kind: ErrConflict, Message: message}
+}
+
+// Validation membuat error ErrValidation
+func Validation(message string) *Error {
+	return &Error{Kind: ErrValidation, Message: message}
+}
+
+// Forbidden membuat error ErrForbidden
+func Forbidden(message string) *Error {
+	return &Error{Kind: ErrForbidden, Message: message}
+}
+
+// PreconditionFailed membuat error ErrPreconditionFailed
+func PreconditionFailed(message string) *Error {
+	return &Error{Kind: ErrPreconditionFailed, Message: message}
+}
+
+// As mengambil *Error dari rantai err, atau nil jika err bukan error domain
+func As(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+)
+
+// AdminSet adalah kumpulan wallet admin platform (dari ADMIN_WALLETS).
+// Admin boleh melakukan aksi pemilik pada project mana pun yang rutenya mengizinkan admin.
+type AdminSet map[string]struct{}
+
+// NewAdminSet membuat AdminSet dari daftar wallet address; entri kosong diabaikan
+func NewAdminSet(wallets []string) AdminSet {
+	admins := make(AdminSet, len(wallets))
+	for _, w := range wallets {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		admins[strings.ToLower(w)] = struct{}{}
+	}
+	return admins
+}
+
+// Contains mengecek apakah wallet adalah admin
+func (a AdminSet) Contains(wallet string) bool {
+	if wallet == "" {
+		return false
+	}
+	_, ok := a[strings.ToLower(wallet)]
+	return ok
+}
+
+// RequireAdmin hanya meneruskan request dari wallet admin. Harus dipasang setelah Middleware.
+func RequireAdmin(admins AdminSet) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		wallet := WalletFromCtx(c)
+		if wallet == "" {
+			return problem.New(fiber.StatusUnauthorized, "Authentication required")
+		}
+		if !admins.Contains(wallet) {
+			return apperror.Forbidden("Admin access required")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CommentSigningMessage menyusun teks yang harus ditandatangani (personal_sign / EIP-191)
+// oleh author sebuah komentar. Formatnya tetap agar pihak ketiga bisa menyusun ulang
+// pesan dari data komentar yang tersimpan dan memverifikasi signature-nya:
+//
+//	Web3 Crowdfunding Comment
+//	Project ID: <project_id>
+//	Parent ID: <parent_comment_id atau "none">
+//	Content:
+//	<content>
+func CommentSigningMessage(projectID uint64, parentCommentID *uint64, content string) string {
+	parent := "none"
+	if parentCommentID != nil {
+		parent = strconv.FormatUint(*parentCommentID, 10)
+	}
+	return fmt.Sprintf("Web3 Crowdfunding Comment\nProject ID: %d\nParent ID: %s\nContent:\n%s", projectID, parent, content)
+}
+
+// VerifyCommentSignature memastikan signature komentar dibuat oleh authorWalletAddress
+func VerifyCommentSignature(projectID uint64, parentCommentID *uint64, content, authorWalletAddress, signature string) error {
+	message := CommentSigningMessage(projectID, parentCommentID, content)
+	signer, err := RecoverAddress([]byte(message), signature)
+	if err != nil {
+		return err
+	}
+	if !SameAddress(signer, authorWalletAddress) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
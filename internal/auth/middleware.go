@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+)
+
+// localsWalletKey adalah key fiber.Ctx.Locals untuk wallet yang sudah terautentikasi
+const localsWalletKey = "auth_wallet"
+
+// Middleware membaca header "Authorization: Bearer <token>" dan, jika valid,
+// menyimpan wallet address ke fiber.Ctx. Request tanpa token tetap diteruskan;
+// gunakan RequireWallet untuk rute yang wajib login.
+func Middleware(tokens *TokenManager) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		if header == "" {
+			return c.Next()
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			return problem.New(fiber.StatusUnauthorized, "Authorization header must use the Bearer scheme")
+		}
+
+		claims, err := tokens.Parse(strings.TrimSpace(token))
+		if err != nil {
+			return problem.New(fiber.StatusUnauthorized, "Invalid or expired session token")
+		}
+
+		c.Locals(localsWalletKey, claims.WalletAddress)
+		return c.Next()
+	}
+}
+
+// RequireWallet menolak request yang belum terautentikasi dengan 401
+func RequireWallet() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if WalletFromCtx(c) == "" {
+			return problem.New(fiber.StatusUnauthorized, "Authentication required")
+		}
+		return c.Next()
+	}
+}
+
+// WalletFromCtx mengembalikan wallet address yang terautentikasi, atau string kosong
+func WalletFromCtx(c *fiber.Ctx) string {
+	wallet, _ := c.Locals(localsWalletKey).(string)
+	return wallet
+}
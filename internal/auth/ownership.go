@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+)
+
+// ProjectOwnership adalah sumber data yang dibutuhkan untuk memeriksa kepemilikan project
+type ProjectOwnership interface {
+	GetByID(id uint64) (*model.Project, error)
+	GetByIDWithDeleted(id uint64) (*model.Project, error)
+	IsCoOwner(projectID uint64, walletAddress string) (bool, error)
+}
+
+// projectRole mengatur siapa saja yang diterima oleh requireProjectRole
+type projectRole struct {
+	allowCoOwner   bool
+	admins         AdminSet // wallet admin yang selalu diterima
+	includeDeleted bool     // cari juga project yang sudah di-soft delete
+}
+
+// RequireProjectOwner hanya meneruskan request dari creator project (Project.CreatorWalletAddress)
+// atau co-owner yang sudah diberi akses. Project diambil dari parameter rute ":id".
+// Harus dipasang setelah Middleware.
+func RequireProjectOwner(projects ProjectOwnership) fiber.Handler {
+	return requireProjectRole(projects, projectRole{allowCoOwner: true})
+}
+
+// RequireProjectCreator hanya meneruskan request dari creator project; co-owner ditolak.
+// Dipakai untuk aksi yang mengubah daftar pemilik itu sendiri.
+func RequireProjectCreator(projects ProjectOwnership) fiber.Handler {
+	return requireProjectRole(projects, projectRole{})
+}
+
+// RequireProjectCreatorOrAdmin seperti RequireProjectCreator, tetapi juga meneruskan request dari admin.
+// Dipakai untuk moderasi komentar project.
+func RequireProjectCreatorOrAdmin(projects ProjectOwnership, admins AdminSet) fiber.Handler {
+	return requireProjectRole(projects, projectRole{admins: admins})
+}
+
+// RequireProjectOwnerOrAdmin seperti RequireProjectOwner, tetapi juga meneruskan request dari admin
+func RequireProjectOwnerOrAdmin(projects ProjectOwnership, admins AdminSet) fiber.Handler {
+	return requireProjectRole(projects, projectRole{allowCoOwner: true, admins: admins})
+}
+
+// RequireDeletedProjectOwnerOrAdmin seperti RequireProjectOwnerOrAdmin, tetapi project yang sudah
+// di-soft delete juga ditemukan. Dipakai untuk restore.
+func RequireDeletedProjectOwnerOrAdmin(projects ProjectOwnership, admins AdminSet) fiber.Handler {
+	return requireProjectRole(projects, projectRole{allowCoOwner: true, admins: admins, includeDeleted: true})
+}
+
+// RequireVisibleProject hanya meneruskan request jika project ":id" ada dan boleh dilihat wallet
+// saat ini (lihat CanViewProject). Project yang tidak terlihat menghasilkan 404, sama seperti
+// GET /projects/:id, agar keberadaan draft tidak bocor lewat sub-resource. Wallet tidak wajib.
+func RequireVisibleProject(projects ProjectOwnership) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+		if err != nil {
+			return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+		}
+
+		project, err := projects.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if project == nil {
+			return apperror.NotFound("Project not found")
+		}
+
+		visible, err := CanViewProject(projects, project, WalletFromCtx(c))
+		if err != nil {
+			return err
+		}
+		if !visible {
+			return apperror.NotFound("Project not found")
+		}
+		return c.Next()
+	}
+}
+
+// CanViewProject mengecek apakah wallet boleh melihat project: project publik terlihat oleh
+// siapa saja, draft dan review hanya oleh creator dan co-owner
+func CanViewProject(projects ProjectOwnership, project *model.Project, wallet string) (bool, error) {
+	if model.ProjectStatusIsPublic(project.Status) {
+		return true, nil
+	}
+	if wallet == "" {
+		return false, nil
+	}
+	if SameAddress(project.CreatorWalletAddress, wallet) {
+		return true, nil
+	}
+	return projects.IsCoOwner(project.ID, wallet)
+}
+
+func requireProjectRole(projects ProjectOwnership, role projectRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		wallet := WalletFromCtx(c)
+		if wallet == "" {
+			return problem.New(fiber.StatusUnauthorized, "Authentication required")
+		}
+
+		id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+		if err != nil {
+			return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+		}
+
+		lookup := projects.GetByID
+		if role.includeDeleted {
+			lookup = projects.GetByIDWithDeleted
+		}
+		project, err := lookup(id)
+		if err != nil {
+			return err
+		}
+		if project == nil {
+			return apperror.NotFound("Project not found")
+		}
+
+		if SameAddress(project.CreatorWalletAddress, wallet) || role.admins.Contains(wallet) {
+			return c.Next()
+		}
+
+		if role.allowCoOwner {
+			isCoOwner, err := projects.IsCoOwner(id, wallet)
+			if err != nil {
+				return err
+			}
+			if isCoOwner {
+				return c.Next()
+			}
+		}
+
+		return apperror.Forbidden("You are not allowed to modify this project")
+	}
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+)
+
+// failingOwnership adalah ProjectOwnership yang selalu gagal dengan err
+type failingOwnership struct{ err error }
+
+func (f failingOwnership) GetByID(uint64) (*model.Project, error) { return nil, f.err }
+
+func (f failingOwnership) GetByIDWithDeleted(uint64) (*model.Project, error) { return nil, f.err }
+
+func (f failingOwnership) IsCoOwner(uint64, string) (bool, error) { return false, f.err }
+
+func TestProjectGuardsPassRepositoryErrorsThrough(t *testing.T) {
+	tokens := NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	token, _, err := tokens.Issue(vectorAddress, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		// Error domain dipetakan oleh problem.ErrorHandler, sama seperti di handler
+		{"domain error", apperror.Conflict("project is being migrated"), fiber.StatusConflict},
+		{"unexpected error", errors.New("connection refused"), fiber.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects := failingOwnership{err: tt.err}
+			app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+			app.Use(Middleware(tokens))
+			ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+			app.Get("/visible/:id", RequireVisibleProject(projects), ok)
+			app.Get("/owner/:id", RequireProjectOwner(projects), ok)
+
+			for _, path := range []string{"/visible/1", "/owner/1"} {
+				req := httptest.NewRequest(fiber.MethodGet, path, nil)
+				req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+				resp, err := app.Test(req, -1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+				if resp.StatusCode != tt.status {
+					t.Fatalf("GET %s: status = %d, want %d", path, resp.StatusCode, tt.status)
+				}
+			}
+		})
+	}
+}
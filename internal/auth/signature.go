@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrInvalidSignature dikembalikan ketika signature tidak bisa dipulihkan menjadi address
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Keccak256 menghitung hash Keccak-256 (varian Ethereum, bukan SHA3 standar)
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// HashPersonalMessage menghitung hash EIP-191 (versi 0x45) yang dipakai oleh personal_sign
+func HashPersonalMessage(message []byte) []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return Keccak256([]byte(prefix), message)
+}
+
+// RecoverAddress memulihkan address penandatangan dari signature personal_sign.
+// Signature berupa hex 65 byte (r || s || v) dengan v bernilai 0/1 atau 27/28.
+// Address dikembalikan dalam format checksum EIP-55.
+func RecoverAddress(message []byte, signatureHex string) (string, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signatureHex, "0x"))
+	if err != nil || len(sig) != 65 {
+		return "", ErrInvalidSignature
+	}
+
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return "", ErrInvalidSignature
+	}
+
+	// decred mengharapkan format compact: [27 + recid] || r || s
+	compact := make([]byte, 65)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+
+	pub, _, err := ecdsa.RecoverCompact(compact, HashPersonalMessage(message))
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	// Address = 20 byte terakhir dari keccak256(pubkey tanpa prefix 0x04)
+	addr := Keccak256(pub.SerializeUncompressed()[1:])[12:]
+	return ChecksumAddress("0x" + hex.EncodeToString(addr)), nil
+}
+
+// IsHexAddress mengecek apakah string adalah address Ethereum (0x + 40 hex)
+func IsHexAddress(s string) bool {
+	if len(s) != 42 || !strings.HasPrefix(s, "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
+}
+
+// ChecksumAddress mengubah address menjadi format checksum EIP-55.
+// Input yang bukan address valid dikembalikan apa adanya.
+func ChecksumAddress(address string) string {
+	if !IsHexAddress(address) {
+		return address
+	}
+	lower := strings.ToLower(address[2:])
+	hash := hex.EncodeToString(Keccak256([]byte(lower)))
+
+	out := []byte(lower)
+	for i, ch := range out {
+		if ch >= 'a' && ch <= 'f' && hash[i] >= '8' {
+			out[i] = ch - 32
+		}
+	}
+	return "0x" + string(out)
+}
+
+// SameAddress membandingkan dua address tanpa memperhatikan huruf besar/kecil
+func SameAddress(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
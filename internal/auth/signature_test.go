@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Vektor uji web3.js: web3.eth.accounts.sign("Some data", privateKey)
+const (
+	vectorMessage   = "Some data"
+	vectorAddress   = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+	vectorSignature = "0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c"
+)
+
+func TestRecoverAddress(t *testing.T) {
+	signer, err := RecoverAddress([]byte(vectorMessage), vectorSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer != vectorAddress {
+		t.Fatalf("signer = %s, want %s", signer, vectorAddress)
+	}
+
+	// v = 0/1 (tanpa offset 27) juga diterima
+	raw := []byte(vectorSignature)
+	lowV := string(raw[:len(raw)-2]) + "01"
+	if signer, err := RecoverAddress([]byte(vectorMessage), lowV); err != nil || signer != vectorAddress {
+		t.Fatalf("v=1: signer = %s, err = %v", signer, err)
+	}
+
+	// Pesan lain memulihkan address lain
+	if signer, err := RecoverAddress([]byte("Other data"), vectorSignature); err == nil && signer == vectorAddress {
+		t.Fatal("signature verified for a different message")
+	}
+}
+
+func TestRecoverAddressRejectsMalformedSignatures(t *testing.T) {
+	tests := map[string]string{
+		"not hex":   "0xzz",
+		"too short": vectorSignature[:len(vectorSignature)-2],
+		"bad v":     vectorSignature[:len(vectorSignature)-2] + "1d",
+		"empty":     "",
+	}
+	for name, sig := range tests {
+		if _, err := RecoverAddress([]byte(vectorMessage), sig); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: err = %v, want ErrInvalidSignature", name, err)
+		}
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	// Vektor dari EIP-55
+	for _, want := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+	} {
+		if got := ChecksumAddress(strings.ToLower(want)); got != want {
+			t.Errorf("ChecksumAddress(%s) = %s, want %s", strings.ToLower(want), got, want)
+		}
+	}
+	if got := ChecksumAddress("not-an-address"); got != "not-an-address" {
+		t.Errorf("invalid input changed to %s", got)
+	}
+}
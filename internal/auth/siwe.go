@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const siweHeaderSuffix = " wants you to sign in with your Ethereum account:"
+
+// siweClockSkew adalah toleransi selisih jam antara wallet dan server untuk Issued At
+const siweClockSkew = 5 * time.Minute
+
+// SIWEPolicy berisi nilai yang wajib cocok dengan pesan SIWE. Domain tidak boleh kosong:
+// pengecekan domain dan URI adalah perlindungan EIP-4361 terhadap phishing.
+type SIWEPolicy struct {
+	Domain   string  // host[:port] situs, mis. "app.example.com"
+	ChainIDs []int64 // chain yang diizinkan
+}
+
+// SIWEMessage merepresentasikan pesan Sign-In With Ethereum (EIP-4361)
+type SIWEMessage struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseSIWEMessage mem-parsing pesan EIP-4361 dalam bentuk teks yang ditandatangani wallet
+func ParseSIWEMessage(raw string) (*SIWEMessage, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], siweHeaderSuffix) {
+		return nil, errors.New("invalid SIWE header")
+	}
+
+	msg := &SIWEMessage{
+		Domain:  strings.TrimSuffix(lines[0], siweHeaderSuffix),
+		Address: lines[1],
+	}
+	if msg.Domain == "" {
+		return nil, errors.New("SIWE domain is empty")
+	}
+	if !IsHexAddress(msg.Address) {
+		return nil, errors.New("invalid SIWE address")
+	}
+
+	// Statement bersifat opsional dan diapit baris kosong
+	i := 2
+	if i < len(lines) && lines[i] == "" {
+		i++
+		if i < len(lines) && !strings.HasPrefix(lines[i], "URI: ") {
+			msg.Statement = lines[i]
+			i++
+			if i < len(lines) && lines[i] == "" {
+				i++
+			}
+		}
+	}
+
+	inResources := false
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if inResources {
+			if strings.HasPrefix(line, "- ") {
+				msg.Resources = append(msg.Resources, strings.TrimPrefix(line, "- "))
+				continue
+			}
+			inResources = false
+		}
+		if line == "" {
+			continue
+		}
+		if line == "Resources:" {
+			inResources = true
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, fmt.Errorf("invalid SIWE line: %q", line)
+		}
+
+		var err error
+		switch key {
+		case "URI":
+			msg.URI = value
+		case "Version":
+			msg.Version = value
+		case "Chain ID":
+			msg.ChainID, err = strconv.ParseInt(value, 10, 64)
+		case "Nonce":
+			msg.Nonce = value
+		case "Issued At":
+			msg.IssuedAt, err = time.Parse(time.RFC3339, value)
+		case "Expiration Time":
+			var t time.Time
+			t, err = time.Parse(time.RFC3339, value)
+			msg.ExpirationTime = &t
+		case "Not Before":
+			var t time.Time
+			t, err = time.Parse(time.RFC3339, value)
+			msg.NotBefore = &t
+		case "Request ID":
+			msg.RequestID = value
+		default:
+			return nil, fmt.Errorf("unknown SIWE field: %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid SIWE %s: %w", key, err)
+		}
+	}
+
+	if msg.URI == "" || msg.Version == "" || msg.Nonce == "" || msg.IssuedAt.IsZero() {
+		return nil, errors.New("SIWE message is missing required fields")
+	}
+	if msg.ChainID == 0 {
+		return nil, errors.New("SIWE chain ID is required")
+	}
+
+	return msg, nil
+}
+
+// Validate memeriksa field pesan terhadap policy dan waktu saat ini: domain dan host URI harus
+// sama dengan policy.Domain, chain ID harus diizinkan, dan pesan harus sedang berlaku.
+func (m *SIWEMessage) Validate(policy SIWEPolicy, now time.Time) error {
+	if m.Version != "1" {
+		return errors.New("unsupported SIWE version")
+	}
+	if policy.Domain == "" {
+		return errors.New("SIWE domain is not configured")
+	}
+	if !strings.EqualFold(m.Domain, policy.Domain) {
+		return errors.New("SIWE domain mismatch")
+	}
+	uri, err := url.Parse(m.URI)
+	if err != nil || (uri.Scheme != "https" && uri.Scheme != "http") || !strings.EqualFold(uri.Host, policy.Domain) {
+		return errors.New("SIWE URI does not match the domain")
+	}
+	if !slices.Contains(policy.ChainIDs, m.ChainID) {
+		return errors.New("SIWE chain ID is not supported")
+	}
+	if m.IssuedAt.After(now.Add(siweClockSkew)) {
+		return errors.New("SIWE message is issued in the future")
+	}
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return errors.New("SIWE message has expired")
+	}
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return errors.New("SIWE message is not yet valid")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const siweTestMessage = `localhost:3000 wants you to sign in with your Ethereum account:
+0x2c7536E3605D9C16a7a3D7b1898e529396a65c23
+
+Sign in to Web3 Crowdfunding
+
+URI: http://localhost:3000/login
+Version: 1
+Chain ID: 1
+Nonce: 9f1c2a7b4e6d8f00a1b2c3d4e5f60718
+Issued At: 2025-10-15T10:00:00Z
+Expiration Time: 2025-10-15T10:10:00Z
+Resources:
+- https://localhost:3000/terms
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq`
+
+var siweTestPolicy = SIWEPolicy{Domain: "localhost:3000", ChainIDs: []int64{1, 11155111}}
+
+func TestParseSIWEMessage(t *testing.T) {
+	msg, err := ParseSIWEMessage(siweTestMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Domain != "localhost:3000" || msg.Address != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" {
+		t.Errorf("domain = %q, address = %q", msg.Domain, msg.Address)
+	}
+	if msg.Statement != "Sign in to Web3 Crowdfunding" || msg.URI != "http://localhost:3000/login" || msg.Version != "1" {
+		t.Errorf("statement = %q, uri = %q, version = %q", msg.Statement, msg.URI, msg.Version)
+	}
+	if msg.ChainID != 1 || msg.Nonce != "9f1c2a7b4e6d8f00a1b2c3d4e5f60718" {
+		t.Errorf("chain id = %d, nonce = %q", msg.ChainID, msg.Nonce)
+	}
+	if !msg.IssuedAt.Equal(time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC)) || msg.ExpirationTime == nil {
+		t.Errorf("issued at = %s, expiration = %v", msg.IssuedAt, msg.ExpirationTime)
+	}
+	if len(msg.Resources) != 2 || !strings.HasPrefix(msg.Resources[1], "ipfs://") {
+		t.Errorf("resources = %v", msg.Resources)
+	}
+
+	// Statement bersifat opsional; CRLF dinormalkan
+	withoutStatement := strings.Replace(siweTestMessage, "Sign in to Web3 Crowdfunding\n\n", "", 1)
+	msg, err = ParseSIWEMessage(strings.ReplaceAll(withoutStatement, "\n", "\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Statement != "" || msg.URI != "http://localhost:3000/login" {
+		t.Errorf("without statement: statement = %q, uri = %q", msg.Statement, msg.URI)
+	}
+}
+
+func TestParseSIWEMessageRejectsInvalidMessages(t *testing.T) {
+	tests := map[string]string{
+		"missing header":   strings.Replace(siweTestMessage, " wants you to sign in with your Ethereum account:", "", 1),
+		"empty domain":     strings.Replace(siweTestMessage, "localhost:3000 wants", " wants", 1),
+		"invalid address":  strings.Replace(siweTestMessage, "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23", "0x1234", 1),
+		"unknown field":    siweTestMessage + "\nFoo: bar",
+		"invalid chain id": strings.Replace(siweTestMessage, "Chain ID: 1", "Chain ID: one", 1),
+		"missing chain id": strings.Replace(siweTestMessage, "Chain ID: 1\n", "", 1),
+		"missing nonce":    strings.Replace(siweTestMessage, "Nonce: 9f1c2a7b4e6d8f00a1b2c3d4e5f60718\n", "", 1),
+		"invalid time":     strings.Replace(siweTestMessage, "2025-10-15T10:00:00Z", "yesterday", 1),
+	}
+	for name, raw := range tests {
+		if _, err := ParseSIWEMessage(raw); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSIWEMessageValidate(t *testing.T) {
+	now := time.Date(2025, 10, 15, 10, 5, 0, 0, time.UTC)
+	parse := func(t *testing.T, raw string) *SIWEMessage {
+		t.Helper()
+		msg, err := ParseSIWEMessage(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+
+	if err := parse(t, siweTestMessage).Validate(siweTestPolicy, now); err != nil {
+		t.Fatalf("valid message rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		raw    string
+		policy SIWEPolicy
+		now    time.Time
+	}{
+		{"unconfigured domain", siweTestMessage, SIWEPolicy{ChainIDs: []int64{1}}, now},
+		{"other domain", siweTestMessage, SIWEPolicy{Domain: "app.example.com", ChainIDs: []int64{1}}, now},
+		{"uri on another host", strings.Replace(siweTestMessage, "URI: http://localhost:3000/login", "URI: https://evil.example.com/login", 1), siweTestPolicy, now},
+		{"uri without scheme", strings.Replace(siweTestMessage, "URI: http://localhost:3000/login", "URI: localhost:3000", 1), siweTestPolicy, now},
+		{"unsupported chain", strings.Replace(siweTestMessage, "Chain ID: 1", "Chain ID: 137", 1), siweTestPolicy, now},
+		{"unsupported version", strings.Replace(siweTestMessage, "Version: 1", "Version: 2", 1), siweTestPolicy, now},
+		{"issued in the future", siweTestMessage, siweTestPolicy, time.Date(2025, 10, 15, 9, 50, 0, 0, time.UTC)},
+		{"expired", siweTestMessage, siweTestPolicy, time.Date(2025, 10, 15, 10, 10, 0, 0, time.UTC)},
+		{"not yet valid", strings.Replace(siweTestMessage, "Resources:", "Not Before: 2025-10-15T10:06:00Z\nResources:", 1), siweTestPolicy, now},
+	}
+	for _, tt := range tests {
+		if err := parse(t, tt.raw).Validate(tt.policy, tt.now); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
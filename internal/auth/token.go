@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken dikembalikan ketika session token tidak valid atau kadaluarsa
+var ErrInvalidToken = errors.New("invalid or expired session token")
+
+// Claims adalah isi session token
+type Claims struct {
+	WalletAddress string `json:"sub"`
+	ChainID       int64  `json:"chain_id,omitempty"`
+	IssuedAt      int64  `json:"iat"`
+	ExpiresAt     int64  `json:"exp"`
+}
+
+// TokenManager menerbitkan dan memverifikasi session token yang ditandatangani HMAC-SHA256.
+// Format token: base64url(claims JSON) + "." + base64url(hmac).
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTokenManager membuat instance baru dari TokenManager
+func NewTokenManager(secret []byte, ttl time.Duration) *TokenManager {
+	return &TokenManager{secret: secret, ttl: ttl}
+}
+
+// Issue membuat session token baru untuk wallet address
+func (m *TokenManager) Issue(walletAddress string, chainID int64) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	payload, err := json.Marshal(Claims{
+		WalletAddress: walletAddress,
+		ChainID:       chainID,
+		IssuedAt:      now.Unix(),
+		ExpiresAt:     expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + m.sign(encoded), expiresAt, nil
+}
+
+// Parse memverifikasi signature dan masa berlaku token lalu mengembalikan claims-nya
+func (m *TokenManager) Parse(token string) (*Claims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(m.sign(encoded))) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.WalletAddress == "" || time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
+
+func (m *TokenManager) sign(encoded string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// NewNonce membuat nonce acak alfanumerik sesuai syarat EIP-4361 (minimal 8 karakter)
+func NewNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
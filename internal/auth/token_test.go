@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenManagerRoundTrip(t *testing.T) {
+	tokens := NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	token, expiresAt, err := tokens.Issue(vectorAddress, 11155111)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Until(expiresAt); d <= 59*time.Minute || d > time.Hour {
+		t.Fatalf("expires in %s, want about 1h", d)
+	}
+
+	claims, err := tokens.Parse(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.WalletAddress != vectorAddress || claims.ChainID != 11155111 || claims.ExpiresAt != expiresAt.Unix() {
+		t.Fatalf("claims = %+v", claims)
+	}
+}
+
+func TestTokenManagerRejectsInvalidTokens(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	tokens := NewTokenManager(secret, time.Hour)
+	token, _, err := tokens.Issue(vectorAddress, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+
+	other, _, err := NewTokenManager(secret, time.Hour).Issue("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPayload, _, _ := strings.Cut(other, ".")
+
+	expired, _, err := NewTokenManager(secret, -time.Minute).Issue(vectorAddress, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"empty":             "",
+		"no signature":      payload,
+		"tampered payload":  otherPayload + "." + sig,
+		"tampered sig":      payload + "." + strings.Repeat("A", len(sig)),
+		"other secret":      mustIssue(t, NewTokenManager([]byte("another-secret-another-secret-xx"), time.Hour)),
+		"expired":           expired,
+		"garbage payload":   "!!!." + sig,
+		"no wallet address": mustSign(tokens, `{"exp":9999999999}`),
+	}
+	for name, tok := range tests {
+		if _, err := tokens.Parse(tok); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: err = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func mustIssue(t *testing.T, tokens *TokenManager) string {
+	t.Helper()
+	token, _, err := tokens.Issue(vectorAddress, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// mustSign menandatangani payload JSON apa adanya, untuk menguji claims yang tidak lengkap
+func mustSign(tokens *TokenManager, payload string) string {
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + tokens.sign(encoded)
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -18,13 +21,44 @@ type Config struct {
 	DBSSLMode      string
 	ServerPort     string
 	MigrateOnStart bool
+
+	// Logging: level slog (debug, info, warn, error), format (json atau text), dan batas
+	// durasi query yang dicatat sebagai slow query (0 = nonaktif)
+	LogLevel             string
+	LogFormat            string
+	DBSlowQueryThreshold time.Duration
+
+	// Sign-In With Ethereum. AuthDomain, AuthSecret dan AuthChainIDs wajib diisi (dicek saat startup).
+	AuthDomain     string  // domain (host[:port]) yang wajib tercantum di pesan SIWE dan di URI-nya
+	AuthSecret     string  // secret HMAC session token, minimal MinAuthSecretLength byte
+	AuthChainIDs   []int64 // chain yang boleh dipakai untuk sign-in (AUTH_CHAIN_IDS, dipisah koma)
+	AuthSessionTTL time.Duration
+	AuthNonceTTL   time.Duration
+	AdminWallets   []string // wallet admin platform (ADMIN_WALLETS, dipisah koma)
+
+	// On-chain indexer. Jika diaktifkan, EthRPCURL, ContractAddress dan IndexerStartBlock wajib diisi.
+	IndexerEnabled       bool
+	EthRPCURL            string
+	ContractAddress      string
+	IndexerChainID       int64
+	IndexerStartBlock    *uint64 // blok deploy kontrak; nil jika INDEXER_START_BLOCK tidak diisi
+	IndexerConfirmations uint64
+	IndexerBlockRange    uint64
+	IndexerPollInterval  time.Duration
+
+	// Lifecycle project: interval pengecekan project live yang deadline-nya lewat (0 = nonaktif)
+	ProjectSettleInterval time.Duration
+
+	// Soft delete project: lama tombstone disimpan sebelum dipurge permanen, dan interval purge (0 = nonaktif)
+	ProjectPurgeRetention time.Duration
+	ProjectPurgeInterval  time.Duration
 }
 
 // LoadConfig memuat konfigurasi dari file .env
 func LoadConfig() *Config {
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: .env file not found, using environment variables")
+		slog.Warn(".env file not found, using environment variables")
 	}
 
 	config := &Config{
@@ -36,11 +70,82 @@ func LoadConfig() *Config {
 		DBSSLMode:      getEnv("DB_SSLMODE", "disable"),
 		ServerPort:     getEnv("SERVER_PORT", "3000"),
 		MigrateOnStart: getEnv("MIGRATE_ON_START", "false") == "true",
+		AuthDomain:     getEnv("AUTH_DOMAIN", ""),
+		AuthSecret:     getEnv("AUTH_SECRET", ""),
+		AuthChainIDs:   getEnvInt64List("AUTH_CHAIN_IDS"),
+		AuthSessionTTL: getEnvDuration("AUTH_SESSION_TTL", 24*time.Hour),
+		AuthNonceTTL:   getEnvDuration("AUTH_NONCE_TTL", 10*time.Minute),
+		AdminWallets:   getEnvList("ADMIN_WALLETS"),
+
+		LogLevel:             getEnv("LOG_LEVEL", "info"),
+		LogFormat:            getEnv("LOG_FORMAT", "json"),
+		DBSlowQueryThreshold: getEnvDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+
+		IndexerEnabled:       getEnv("INDEXER_ENABLED", "false") == "true",
+		EthRPCURL:            getEnv("ETH_RPC_URL", ""),
+		ContractAddress:      getEnv("CROWDFUNDING_CONTRACT_ADDRESS", ""),
+		IndexerChainID:       int64(getEnvUint("INDEXER_CHAIN_ID", 0)),
+		IndexerStartBlock:    getEnvOptionalUint("INDEXER_START_BLOCK"),
+		IndexerConfirmations: getEnvUint("INDEXER_CONFIRMATIONS", 12),
+		IndexerBlockRange:    getEnvUint("INDEXER_BLOCK_RANGE", 2000),
+		IndexerPollInterval:  getEnvDuration("INDEXER_POLL_INTERVAL", 15*time.Second),
+
+		ProjectSettleInterval: getEnvDuration("PROJECT_SETTLE_INTERVAL", time.Minute),
+		ProjectPurgeRetention: getEnvDuration("PROJECT_PURGE_RETENTION", 30*24*time.Hour),
+		ProjectPurgeInterval:  getEnvDuration("PROJECT_PURGE_INTERVAL", time.Hour),
 	}
 
 	return config
 }
 
+// MinAuthSecretLength adalah panjang minimal AUTH_SECRET agar HMAC session token tidak mudah ditebak
+const MinAuthSecretLength = 32
+
+// ValidateAuth memastikan konfigurasi Sign-In With Ethereum lengkap. Tanpa AUTH_DOMAIN pesan SIWE
+// untuk situs lain (phishing) ikut diterima, dan tanpa AUTH_SECRET yang tetap session token tidak
+// berlaku lagi setelah restart maupun di replika lain.
+func (c *Config) ValidateAuth() error {
+	var missing []string
+	if c.AuthDomain == "" {
+		missing = append(missing, "AUTH_DOMAIN")
+	}
+	if c.AuthSecret == "" {
+		missing = append(missing, "AUTH_SECRET")
+	}
+	if len(c.AuthChainIDs) == 0 {
+		missing = append(missing, "AUTH_CHAIN_IDS")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s must be set", strings.Join(missing, ", "))
+	}
+	if len(c.AuthSecret) < MinAuthSecretLength {
+		return fmt.Errorf("AUTH_SECRET must be at least %d bytes", MinAuthSecretLength)
+	}
+	return nil
+}
+
+// ValidateIndexer memastikan konfigurasi indexer lengkap jika diaktifkan. INDEXER_START_BLOCK harus
+// diisi secara eksplisit agar indexer tidak diam-diam memindai chain dari blok genesis.
+func (c *Config) ValidateIndexer() error {
+	if !c.IndexerEnabled {
+		return nil
+	}
+	var missing []string
+	if c.EthRPCURL == "" {
+		missing = append(missing, "ETH_RPC_URL")
+	}
+	if c.ContractAddress == "" {
+		missing = append(missing, "CROWDFUNDING_CONTRACT_ADDRESS")
+	}
+	if c.IndexerStartBlock == nil {
+		missing = append(missing, "INDEXER_START_BLOCK")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("INDEXER_ENABLED requires %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // GetDSN mengembalikan Data Source Name untuk koneksi PostgreSQL
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf(
@@ -62,3 +167,72 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getEnvDuration membaca environment variable berformat durasi Go (mis. "15m", "24h")
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Warn("invalid duration, using default", "key", key, "value", value, "default", defaultValue.String())
+		return defaultValue
+	}
+	return d
+}
+
+// getEnvUint membaca environment variable berupa bilangan bulat non-negatif
+func getEnvUint(key string, defaultValue uint64) uint64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		slog.Warn("invalid number, using default", "key", key, "value", value, "default", defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getEnvOptionalUint membaca environment variable berupa bilangan bulat non-negatif yang wajib
+// diisi caller; nil jika kosong atau tidak valid
+func getEnvOptionalUint(key string) *uint64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		slog.Warn("invalid number, ignoring", "key", key, "value", value)
+		return nil
+	}
+	return &n
+}
+
+// getEnvList membaca environment variable berisi daftar yang dipisah koma
+func getEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvInt64List membaca environment variable berisi daftar bilangan bulat yang dipisah koma.
+// Item yang tidak valid dilewati dengan peringatan.
+func getEnvInt64List(key string) []int64 {
+	var items []int64
+	for _, item := range getEnvList(key) {
+		n, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			slog.Warn("invalid number in list, skipping", "key", key, "value", item)
+			continue
+		}
+		items = append(items, n)
+	}
+	return items
+}
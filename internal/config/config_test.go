@@ -0,0 +1,61 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAuth(t *testing.T) {
+	valid := Config{
+		AuthDomain:   "app.example.com",
+		AuthSecret:   strings.Repeat("s", MinAuthSecretLength),
+		AuthChainIDs: []int64{1},
+	}
+	if err := valid.ValidateAuth(); err != nil {
+		t.Fatalf("valid config rejected: %v", err)
+	}
+
+	tests := map[string]func(c *Config){
+		"missing domain":   func(c *Config) { c.AuthDomain = "" },
+		"missing secret":   func(c *Config) { c.AuthSecret = "" },
+		"short secret":     func(c *Config) { c.AuthSecret = "change_me" },
+		"missing chain id": func(c *Config) { c.AuthChainIDs = nil },
+	}
+	for name, mutate := range tests {
+		cfg := valid
+		mutate(&cfg)
+		if err := cfg.ValidateAuth(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestValidateIndexer(t *testing.T) {
+	if err := (&Config{}).ValidateIndexer(); err != nil {
+		t.Fatalf("disabled indexer rejected: %v", err)
+	}
+
+	genesis := uint64(0)
+	valid := Config{
+		IndexerEnabled:    true,
+		EthRPCURL:         "http://localhost:8545",
+		ContractAddress:   "0x5fbdb2315678afecb367f032d93f642f64180aa3",
+		IndexerStartBlock: &genesis, // 0 yang eksplisit tetap boleh, mis. untuk chain lokal
+	}
+	if err := valid.ValidateIndexer(); err != nil {
+		t.Fatalf("valid config rejected: %v", err)
+	}
+
+	tests := map[string]func(c *Config){
+		"missing rpc url":     func(c *Config) { c.EthRPCURL = "" },
+		"missing contract":    func(c *Config) { c.ContractAddress = "" },
+		"missing start block": func(c *Config) { c.IndexerStartBlock = nil },
+	}
+	for name, mutate := range tests {
+		cfg := valid
+		mutate(&cfg)
+		if err := cfg.ValidateIndexer(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,24 +1,24 @@
 package database
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/kevinchr/web3-crowdfunding-api/internal/config"
-	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/logging"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 var DB *gorm.DB
 
-// InitDatabase menginisialisasi koneksi database dan melakukan migrasi
-func InitDatabase(cfg *config.Config) error {
+// Connect membuka koneksi database tanpa menjalankan migrasi
+func Connect(cfg *config.Config) error {
 	var err error
 
-	// Konfigurasi GORM
+	// Konfigurasi GORM: SQL dicatat lewat slog tanpa nilai parameter, hanya jika gagal atau
+	// lebih lambat dari DB_SLOW_QUERY_THRESHOLD (semua query pada LOG_LEVEL=debug)
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: logging.NewGormLogger(cfg.DBSlowQueryThreshold),
 	}
 
 	// Buka koneksi ke database
@@ -27,24 +27,36 @@ func InitDatabase(cfg *config.Config) error {
 		return err
 	}
 
-	log.Println("Database connection established")
-
-	// Auto migrate semua model jika diizinkan oleh konfigurasi
-	if cfg.MigrateOnStart {
-		err = DB.AutoMigrate(
-			&model.Project{},
-			&model.UserProfile{},
-			&model.Comment{},
-			&model.ExternalLink{},
-		)
-		if err != nil {
-			return err
-		}
-
-		log.Println("Database migration completed")
-	} else {
-		log.Println("Skipping auto-migration (MIGRATE_ON_START is false)")
+	slog.Info("database connection established")
+	return nil
+}
+
+// InitDatabase menginisialisasi koneksi database dan menjalankan migrasi SQL
+// yang tertunda jika MIGRATE_ON_START aktif
+func InitDatabase(cfg *config.Config) error {
+	if err := Connect(cfg); err != nil {
+		return err
+	}
+
+	if !cfg.MigrateOnStart {
+		slog.Info("skipping migrations (MIGRATE_ON_START is false)")
+		return nil
+	}
+
+	migrator, err := NewMigrator(DB)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range applied {
+		slog.Info("applied migration", "version", m.Version, "name", m.Name)
 	}
+	slog.Info("database migration completed", "applied", len(applied))
 
 	return nil
 }
@@ -0,0 +1,246 @@
+package database
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey adalah key pg_advisory_lock yang dipakai bersama oleh semua replika API
+// agar hanya satu proses yang menjalankan migrasi pada satu waktu
+const migrationLockKey int64 = 7_204_918_330_113
+
+// Migration adalah satu pasangan file up/down, mis. "0001_initial_schema.up.sql"
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus menggambarkan status satu migrasi pada database
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// schemaMigration merepresentasikan tabel schema_migrations
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator menjalankan migrasi SQL yang di-embed ke dalam binary
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator membuat instance baru dari Migrator dan memuat semua file migrasi
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// loadMigrations membaca dan mengurutkan file <version>_<name>.(up|down).sql
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		file := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(file, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %q", file)
+		}
+		versionStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %q", file)
+		}
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q", file)
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join("migrations", file))
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up menjalankan semua migrasi yang belum diterapkan secara berurutan
+func (m *Migrator) Up() ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := m.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+				}
+				return tx.Create(&schemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down membatalkan sejumlah migrasi terakhir yang sudah diterapkan (urutan terbalik)
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := m.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+				}
+				return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+			})
+			if err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status mengembalikan semua migrasi yang diketahui beserta waktu penerapannya (nil = pending)
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := m.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if row, ok := applied[migration.Version]; ok {
+				appliedAt := row.AppliedAt
+				status.AppliedAt = &appliedAt
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+
+		// Versi yang tercatat di database tetapi file-nya tidak ada di binary ini
+		for _, row := range applied {
+			appliedAt := row.AppliedAt
+			statuses = append(statuses, MigrationStatus{Version: row.Version, Name: row.Name + " (missing file)", AppliedAt: &appliedAt})
+		}
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Version < statuses[j].Version
+		})
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock menjalankan fn pada satu koneksi yang memegang advisory lock migrasi.
+// Advisory lock terikat ke sesi, jadi semua query harus memakai koneksi yang sama.
+func (m *Migrator) withLock(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+
+		if err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL
+		)`).Error; err != nil {
+			return err
+		}
+
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) appliedVersions(conn *gorm.DB) (map[int64]schemaMigration, error) {
+	var rows []schemaMigration
+	if err := conn.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
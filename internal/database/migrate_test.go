@@ -0,0 +1,19 @@
+package database
+
+import "testing"
+
+func TestLoadMigrationsIsContiguous(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	// Versi harus berurutan tanpa celah agar setiap langkah membangun skema yang sama
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Fatalf("migration %d_%s has version %d, want %d", m.Version, m.Name, m.Version, i+1)
+		}
+	}
+}
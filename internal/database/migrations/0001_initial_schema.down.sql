@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS external_links;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS user_profiles;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,55 @@
+-- Skema awal (setara dengan hasil GORM AutoMigrate sebelumnya).
+-- Memakai IF NOT EXISTS agar database lama yang dibuat oleh AutoMigrate bisa langsung diadopsi.
+
+CREATE TABLE IF NOT EXISTS projects (
+    id                        BIGSERIAL PRIMARY KEY,
+    creator_wallet_address    VARCHAR(42)  NOT NULL,
+    title                     VARCHAR(255) NOT NULL,
+    description               TEXT,
+    cover_image_url           VARCHAR(255),
+    developer_name            VARCHAR(100),
+    genre                     VARCHAR(50),
+    game_type                 VARCHAR(10),
+    investor_wallet_addresses TEXT[],
+    created_at                TIMESTAMPTZ,
+    updated_at                TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS user_profiles (
+    wallet_address    VARCHAR(42) PRIMARY KEY,
+    username          VARCHAR(50)  NOT NULL,
+    email             VARCHAR(255) NOT NULL,
+    profile_image_url VARCHAR(255),
+    kyc_status        VARCHAR(20) DEFAULT 'unverified',
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ,
+    CONSTRAINT uni_user_profiles_username UNIQUE (username),
+    CONSTRAINT uni_user_profiles_email UNIQUE (email)
+);
+
+CREATE TABLE IF NOT EXISTS comments (
+    id                    BIGSERIAL PRIMARY KEY,
+    project_id            BIGINT      NOT NULL,
+    author_wallet_address VARCHAR(42) NOT NULL,
+    parent_comment_id     BIGINT,
+    content               TEXT        NOT NULL,
+    created_at            TIMESTAMPTZ,
+    updated_at            TIMESTAMPTZ,
+    CONSTRAINT fk_comments_project FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+    CONSTRAINT fk_comments_parent_comment FOREIGN KEY (parent_comment_id) REFERENCES comments (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_project_id ON comments (project_id);
+CREATE INDEX IF NOT EXISTS idx_comments_parent_comment_id ON comments (parent_comment_id);
+
+CREATE TABLE IF NOT EXISTS external_links (
+    id         BIGSERIAL PRIMARY KEY,
+    project_id BIGINT       NOT NULL,
+    name       VARCHAR(50)  NOT NULL,
+    url        VARCHAR(500) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_projects_links FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_links_project_id ON external_links (project_id);
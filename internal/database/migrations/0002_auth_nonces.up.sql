@@ -0,0 +1,10 @@
+-- Sign-In With Ethereum: nonce sekali pakai untuk pesan SIWE
+
+CREATE TABLE IF NOT EXISTS auth_nonces (
+    nonce      VARCHAR(64) PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_nonces_expires_at ON auth_nonces (expires_at);
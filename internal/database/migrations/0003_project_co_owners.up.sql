@@ -0,0 +1,10 @@
+-- Co-owner project: wallet tambahan yang boleh mengubah project selain creator
+
+CREATE TABLE IF NOT EXISTS project_co_owners (
+    project_id     BIGINT      NOT NULL,
+    wallet_address VARCHAR(42) NOT NULL,
+    granted_by     VARCHAR(42) NOT NULL,
+    created_at     TIMESTAMPTZ,
+    PRIMARY KEY (project_id, wallet_address),
+    CONSTRAINT fk_project_co_owners_project FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
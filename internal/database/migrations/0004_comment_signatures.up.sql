@@ -0,0 +1,4 @@
+-- Signature EIP-191 komentar, disimpan agar bisa diverifikasi ulang.
+-- Komentar lama tidak punya signature dan diisi string kosong.
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS signature VARCHAR(132) NOT NULL DEFAULT '';
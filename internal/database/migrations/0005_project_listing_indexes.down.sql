@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_projects_creator_wallet_address;
+DROP INDEX IF EXISTS idx_projects_created_at_id;
@@ -0,0 +1,3 @@
+-- Index untuk keyset pagination (created_at, id) dan filter creator pada GET /projects
+CREATE INDEX IF NOT EXISTS idx_projects_created_at_id ON projects (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_projects_creator_wallet_address ON projects (creator_wallet_address);
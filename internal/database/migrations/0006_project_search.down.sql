@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_projects_search_vector_id;
+DROP INDEX IF EXISTS idx_projects_search_vector_en;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector_en;
@@ -0,0 +1,21 @@
+-- Full-text search: tsvector berbobot untuk bahasa Inggris dan Indonesia.
+-- Bobot: title (A), developer_name & genre (B), description (C).
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS search_vector_en tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(developer_name, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(genre, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'C')
+    ) STORED;
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS search_vector_id tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('indonesian', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('indonesian', coalesce(developer_name, '')), 'B') ||
+        setweight(to_tsvector('indonesian', coalesce(genre, '')), 'B') ||
+        setweight(to_tsvector('indonesian', coalesce(description, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_projects_search_vector_en ON projects USING GIN (search_vector_en);
+CREATE INDEX IF NOT EXISTS idx_projects_search_vector_id ON projects USING GIN (search_vector_id);
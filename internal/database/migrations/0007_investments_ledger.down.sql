@@ -0,0 +1,12 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS investor_wallet_addresses TEXT[];
+
+UPDATE projects p
+SET investor_wallet_addresses = agg.addrs
+FROM (
+    SELECT project_id, array_agg(DISTINCT wallet_address) AS addrs
+    FROM investments
+    GROUP BY project_id
+) agg
+WHERE agg.project_id = p.id;
+
+DROP TABLE IF EXISTS investments;
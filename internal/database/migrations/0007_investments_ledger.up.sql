@@ -0,0 +1,36 @@
+-- Ledger investasi menggantikan kolom projects.investor_wallet_addresses (text[]).
+-- amount disimpan dalam satuan terkecil token (wei) sebagai NUMERIC(78,0) agar muat uint256.
+-- source membedakan entri manual (dicatat pemilik project) dari entri hasil indexer. Entri manual
+-- tidak boleh membawa tx_hash, sehingga tidak bisa merebut key (chain_id, tx_hash, log_index)
+-- milik event on-chain yang asli.
+
+CREATE TABLE IF NOT EXISTS investments (
+    id             BIGSERIAL PRIMARY KEY,
+    project_id     BIGINT        NOT NULL,
+    wallet_address VARCHAR(42)   NOT NULL,
+    source         VARCHAR(16)   NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'indexed')),
+    amount         NUMERIC(78,0) NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    token_address  VARCHAR(42)   NOT NULL DEFAULT '',
+    chain_id       BIGINT        NOT NULL DEFAULT 0,
+    tx_hash        VARCHAR(66),
+    log_index      INTEGER       NOT NULL DEFAULT 0,
+    block_number   BIGINT,
+    invested_at    TIMESTAMPTZ   NOT NULL,
+    created_at     TIMESTAMPTZ,
+    CONSTRAINT fk_investments_project FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+    CONSTRAINT chk_investments_manual_without_tx CHECK (source = 'indexed' OR tx_hash IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_investments_project_id_invested_at ON investments (project_id, invested_at);
+CREATE INDEX IF NOT EXISTS idx_investments_wallet_address ON investments (LOWER(wallet_address));
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_investments_chain_tx_log ON investments (chain_id, tx_hash, log_index)
+    WHERE tx_hash IS NOT NULL;
+
+-- Pindahkan investor lama sebagai entri tanpa nominal/transaksi
+INSERT INTO investments (project_id, wallet_address, amount, invested_at, created_at)
+SELECT p.id, w.addr, 0, COALESCE(p.updated_at, now()), now()
+FROM projects p
+CROSS JOIN LATERAL unnest(p.investor_wallet_addresses) AS w(addr)
+WHERE w.addr IS NOT NULL AND w.addr <> '';
+
+ALTER TABLE projects DROP COLUMN IF EXISTS investor_wallet_addresses;
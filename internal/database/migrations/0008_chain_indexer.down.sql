@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS indexer_pending_events;
+DROP TABLE IF EXISTS indexer_checkpoints;
+ALTER TABLE investments DROP COLUMN IF EXISTS kind;
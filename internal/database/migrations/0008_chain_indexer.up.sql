@@ -0,0 +1,29 @@
+-- Indexer on-chain: jenis entri ledger (investasi / refund), checkpoint blok terakhir, dan event
+-- untuk project yang belum ada. Event tertunda tidak memakai foreign key ke projects; indexer
+-- memindahkannya ke investments begitu project-nya dibuat.
+
+ALTER TABLE investments ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'investment';
+
+CREATE TABLE IF NOT EXISTS indexer_checkpoints (
+    name       VARCHAR(100) PRIMARY KEY,
+    chain_id   BIGINT       NOT NULL,
+    last_block BIGINT       NOT NULL,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS indexer_pending_events (
+    chain_id       BIGINT        NOT NULL,
+    tx_hash        VARCHAR(66)   NOT NULL,
+    log_index      INTEGER       NOT NULL,
+    project_id     BIGINT        NOT NULL,
+    wallet_address VARCHAR(42)   NOT NULL,
+    kind           VARCHAR(16)   NOT NULL,
+    amount         NUMERIC(78,0) NOT NULL CHECK (amount >= 0),
+    token_address  VARCHAR(42)   NOT NULL DEFAULT '',
+    block_number   BIGINT        NOT NULL,
+    invested_at    TIMESTAMPTZ   NOT NULL,
+    created_at     TIMESTAMPTZ,
+    PRIMARY KEY (chain_id, tx_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_indexer_pending_events_project_id ON indexer_pending_events (project_id);
@@ -0,0 +1,7 @@
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS end_date,
+    DROP COLUMN IF EXISTS start_date,
+    DROP COLUMN IF EXISTS currency_chain_id,
+    DROP COLUMN IF EXISTS currency_token_address,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS goal_amount;
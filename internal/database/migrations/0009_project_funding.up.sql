@@ -0,0 +1,11 @@
+-- Target pendanaan project: nominal target (satuan terkecil token), mata uang (simbol, alamat token
+-- dan chain-nya), dan periode kampanye. currency_chain_id default 1 (Ethereum mainnet), sama seperti
+-- currency default ETH; project di chain lain harus mengisinya agar funding_progress benar.
+
+ALTER TABLE projects
+    ADD COLUMN IF NOT EXISTS goal_amount            NUMERIC(78,0) CHECK (goal_amount > 0),
+    ADD COLUMN IF NOT EXISTS currency               VARCHAR(10)  NOT NULL DEFAULT 'ETH',
+    ADD COLUMN IF NOT EXISTS currency_token_address VARCHAR(42)  NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS currency_chain_id      BIGINT       NOT NULL DEFAULT 1 CHECK (currency_chain_id > 0),
+    ADD COLUMN IF NOT EXISTS start_date             TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS end_date               TIMESTAMPTZ;
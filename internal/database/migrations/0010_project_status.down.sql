@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_projects_live_end_date;
+DROP INDEX IF EXISTS idx_projects_status;
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS status;
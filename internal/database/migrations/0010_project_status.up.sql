@@ -0,0 +1,19 @@
+-- Lifecycle project: draft -> review -> live -> funded/failed -> archived.
+--
+-- Back-fill: sebelum lifecycle ada, semua project langsung tampil publik dan bisa menerima
+-- investor. Project yang sudah ada karena itu ditandai live (bukan draft), agar tidak tiba-tiba
+-- tersembunyi dari semua orang kecuali pemiliknya dan tidak perlu melewati review ulang.
+-- status_changed_at diisi waktu perubahan terakhir project. Project baru dimulai sebagai draft.
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'draft'
+    CHECK (status IN ('draft', 'review', 'live', 'funded', 'failed', 'archived'));
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
+
+-- Semua baris pada titik ini dibuat sebelum migrasi ini
+UPDATE projects
+SET status = 'live',
+    status_changed_at = COALESCE(updated_at, created_at, now());
+
+CREATE INDEX IF NOT EXISTS idx_projects_status ON projects (status);
+-- Dipakai settler untuk mencari project live yang deadline-nya sudah lewat
+CREATE INDEX IF NOT EXISTS idx_projects_live_end_date ON projects (end_date) WHERE status = 'live';
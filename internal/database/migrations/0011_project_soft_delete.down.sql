@@ -0,0 +1,4 @@
+-- Project yang masih ter-tombstone akan terlihat kembali setelah rollback
+DROP INDEX IF EXISTS idx_projects_deleted_at;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,6 @@
+-- Soft delete project: baris tetap ada (beserta comments, links, ledger) sampai dipurge
+-- setelah masa retensi PROJECT_PURGE_RETENTION.
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects (deleted_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS comment_revisions;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS edited_at;
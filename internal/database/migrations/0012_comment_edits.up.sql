@@ -0,0 +1,18 @@
+-- Edit dan hapus komentar: riwayat revisi, penanda edited_at, dan placeholder "[deleted]"
+-- untuk komentar yang masih punya balasan
+
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS edited_at  TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    id         BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT       NOT NULL,
+    content    TEXT         NOT NULL,
+    signature  VARCHAR(132) NOT NULL DEFAULT '',
+    edited_at  TIMESTAMPTZ  NOT NULL,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_comment_revisions_comment FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_revisions_comment_id ON comment_revisions (comment_id, id);
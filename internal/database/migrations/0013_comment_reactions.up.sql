@@ -0,0 +1,13 @@
+-- Reaksi komentar: satu reaksi per wallet per jenis untuk setiap komentar
+
+CREATE TABLE IF NOT EXISTS comment_reactions (
+    id             BIGSERIAL PRIMARY KEY,
+    comment_id     BIGINT      NOT NULL,
+    wallet_address VARCHAR(42) NOT NULL,
+    type           VARCHAR(16) NOT NULL,
+    created_at     TIMESTAMPTZ,
+    CONSTRAINT fk_comment_reactions_comment FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_comment_reactions_comment_wallet_type
+    ON comment_reactions (comment_id, LOWER(wallet_address), type);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS comment_reports;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS hidden_by,
+    DROP COLUMN IF EXISTS hidden_at;
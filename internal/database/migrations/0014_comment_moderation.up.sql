@@ -0,0 +1,22 @@
+-- Moderasi komentar: laporan dari user dan penanda komentar yang disembunyikan moderator
+
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS hidden_by VARCHAR(42);
+
+CREATE TABLE IF NOT EXISTS comment_reports (
+    id                      BIGSERIAL PRIMARY KEY,
+    comment_id              BIGINT       NOT NULL,
+    reporter_wallet_address VARCHAR(42)  NOT NULL,
+    reason                  VARCHAR(500) NOT NULL,
+    resolution              VARCHAR(16),
+    resolved_by             VARCHAR(42),
+    resolved_at             TIMESTAMPTZ,
+    created_at              TIMESTAMPTZ,
+    CONSTRAINT fk_comment_reports_comment FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+
+-- Satu laporan terbuka per wallet per komentar; wallet boleh melapor lagi setelah laporannya diselesaikan
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_comment_reports_open_reporter
+    ON comment_reports (comment_id, LOWER(reporter_wallet_address)) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_comment_reports_open ON comment_reports (comment_id) WHERE resolved_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_external_links_project_id_position;
+
+ALTER TABLE external_links
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS type;
@@ -0,0 +1,22 @@
+-- External link: jenis platform (enum di aplikasi) dan urutan tampilan eksplisit
+
+ALTER TABLE external_links
+    ADD COLUMN IF NOT EXISTS type     VARCHAR(20) NOT NULL DEFAULT 'other',
+    ADD COLUMN IF NOT EXISTS position INTEGER     NOT NULL DEFAULT 0;
+
+-- Link lama yang namanya sama dengan platform yang dikenal langsung diberi jenisnya
+UPDATE external_links
+SET type = LOWER(name)
+WHERE LOWER(name) IN ('website', 'twitter', 'discord', 'telegram', 'steam', 'epic', 'itchio',
+                      'youtube', 'twitch', 'instagram', 'tiktok', 'facebook', 'reddit', 'github', 'whitepaper');
+
+-- Pertahankan urutan lama (created_at, id) sebagai posisi awal
+UPDATE external_links l
+SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY created_at, id) - 1 AS position
+    FROM external_links
+) AS ordered
+WHERE l.id = ordered.id;
+
+CREATE INDEX IF NOT EXISTS idx_external_links_project_id_position ON external_links (project_id, position);
@@ -0,0 +1,5 @@
+-- Versi project untuk optimistic concurrency (ETag / If-Match).
+-- Dinaikkan setiap kali baris project atau external link-nya berubah.
+
+ALTER TABLE projects
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
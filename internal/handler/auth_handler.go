@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/auth"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// AuthHandler menangani HTTP requests untuk Sign-In With Ethereum
+type AuthHandler struct {
+	nonceRepo repository.AuthNonceStore
+	tokens    *auth.TokenManager
+	policy    auth.SIWEPolicy
+	nonceTTL  time.Duration
+}
+
+// NewAuthHandler membuat instance baru dari AuthHandler
+func NewAuthHandler(nonceRepo repository.AuthNonceStore, tokens *auth.TokenManager, policy auth.SIWEPolicy, nonceTTL time.Duration) *AuthHandler {
+	return &AuthHandler{
+		nonceRepo: nonceRepo,
+		tokens:    tokens,
+		policy:    policy,
+		nonceTTL:  nonceTTL,
+	}
+}
+
+// GetNonce godoc
+// @Summary      Get SIWE nonce
+// @Description  Issue a single-use nonce to be embedded in an EIP-4361 (Sign-In With Ethereum) message
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  model.NonceResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /auth/nonce [get]
+func (h *AuthHandler) GetNonce(c *fiber.Ctx) error {
+	value, err := auth.NewNonce()
+	if err != nil {
+		return problem.New(fiber.StatusInternalServerError, "Failed to generate nonce")
+	}
+
+	nonce := model.AuthNonce{
+		Nonce:     value,
+		ExpiresAt: time.Now().Add(h.nonceTTL),
+	}
+	if err := h.nonceRepo.WithContext(c.UserContext()).Create(&nonce); err != nil {
+		return storeError(err, "Failed to store nonce")
+	}
+
+	return c.JSON(fiber.Map{
+		"nonce":      nonce.Nonce,
+		"expires_at": nonce.ExpiresAt,
+	})
+}
+
+// Verify godoc
+// @Summary      Verify SIWE signature
+// @Description  Verify a signed EIP-4361 message (personal_sign) and issue a session token for the recovered wallet.
+// @Description  The message domain and URI host must equal AUTH_DOMAIN, the chain ID must be listed in AUTH_CHAIN_IDS, and Issued At must not be in the future
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.SIWEVerifyRequest  true  "Signed SIWE message"
+// @Success      200   {object}  model.SessionResponse
+// @Failure      400   {object}  model.ErrorResponse
+// @Failure      401   {object}  model.ErrorResponse
+// @Failure      500   {object}  model.ErrorResponse
+// @Router       /auth/verify [post]
+func (h *AuthHandler) Verify(c *fiber.Ctx) error {
+	var body model.SIWEVerifyRequest
+	if err := bind(c, &body); err != nil {
+		return err
+	}
+
+	msg, err := auth.ParseSIWEMessage(body.Message)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := msg.Validate(h.policy, time.Now()); err != nil {
+		return problem.New(fiber.StatusUnauthorized, err.Error())
+	}
+
+	signer, err := auth.RecoverAddress([]byte(body.Message), body.Signature)
+	if err != nil || !auth.SameAddress(signer, msg.Address) {
+		return problem.New(fiber.StatusUnauthorized, "Signature does not match the SIWE address")
+	}
+
+	// Nonce hanya dikonsumsi setelah signature valid agar tidak bisa dibakar oleh pihak lain
+	ok, err := h.nonceRepo.WithContext(c.UserContext()).Consume(msg.Nonce)
+	if err != nil {
+		return storeError(err, "Failed to verify nonce")
+	}
+	if !ok {
+		return problem.New(fiber.StatusUnauthorized, "Nonce is invalid, expired or already used")
+	}
+
+	token, expiresAt, err := h.tokens.Issue(signer, msg.ChainID)
+	if err != nil {
+		return problem.New(fiber.StatusInternalServerError, "Failed to issue session token")
+	}
+
+	return c.JSON(fiber.Map{
+		"token":          token,
+		"wallet_address": signer,
+		"expires_at":     expiresAt,
+	})
+}
+
+// Me godoc
+// @Summary      Current session
+// @Description  Return the wallet address attached to the bearer session token
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  model.AuthMeResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Router       /auth/me [get]
+func (h *AuthHandler) Me(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"wallet_address": auth.WalletFromCtx(c),
+	})
+}
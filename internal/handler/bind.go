@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/validate"
+)
+
+// mergePatchContentType adalah media type JSON Merge Patch (RFC 7396)
+const mergePatchContentType = "application/merge-patch+json"
+
+// normalizer diimplementasikan DTO yang perlu merapikan input (trim, lowercase, ...) sebelum divalidasi
+type normalizer interface {
+	Normalize()
+}
+
+// bind mem-parse body request ke dst, merapikannya jika dst adalah normalizer, lalu
+// menjalankan validasi deklaratif dari tag `validate`. Semua pelanggaran dikembalikan
+// sekaligus sebagai apperror.ErrValidation dengan detail per field.
+func bind(c *fiber.Ctx, dst interface{}) error {
+	if err := c.BodyParser(dst); err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid request body")
+	}
+	return normalizeAndValidate(dst)
+}
+
+// bindMergePatch mem-parse body JSON Merge Patch (RFC 7396) ke dst (pointer ke struct) lalu
+// merapikan dan memvalidasinya seperti bind. Hanya key top-level yang ada di tag json dst yang
+// diterima. Hasilnya adalah key yang dikirim client; nilainya true jika key itu bernilai null,
+// yang berarti field dikosongkan.
+func bindMergePatch(c *fiber.Ctx, dst interface{}) (map[string]bool, error) {
+	mediaType, _, _ := strings.Cut(c.Get(fiber.HeaderContentType), ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType != fiber.MIMEApplicationJSON && mediaType != mergePatchContentType {
+		return nil, problem.New(fiber.StatusUnsupportedMediaType, "Content-Type must be "+mergePatchContentType+" or "+fiber.MIMEApplicationJSON)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(c.Body(), &raw); err != nil || raw == nil {
+		return nil, problem.New(fiber.StatusBadRequest, "Request body must be a JSON object")
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	allowed := jsonFieldNames(reflect.TypeOf(dst).Elem())
+	sent := make(map[string]bool, len(raw))
+	var fields []apperror.FieldError
+	for _, key := range keys {
+		if !allowed[key] {
+			fields = append(fields, apperror.FieldError{Field: key, Message: key + " cannot be patched"})
+			continue
+		}
+		sent[key] = bytes.Equal(bytes.TrimSpace(raw[key]), []byte("null"))
+	}
+	if err := validationError(fields); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(c.Body(), dst); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return nil, validationError([]apperror.FieldError{{
+				Field:   typeErr.Field,
+				Message: fmt.Sprintf("%s must not be a JSON %s", typeErr.Field, typeErr.Value),
+			}})
+		}
+		return nil, problem.New(fiber.StatusBadRequest, "Invalid request body")
+	}
+	return sent, normalizeAndValidate(dst)
+}
+
+// normalizeAndValidate merapikan dst jika dst adalah normalizer lalu menjalankan validasi tag
+func normalizeAndValidate(dst interface{}) error {
+	if n, ok := dst.(normalizer); ok {
+		n.Normalize()
+	}
+	return validationError(validate.Struct(dst))
+}
+
+// jsonFieldNames mengembalikan nama JSON field-field struct t
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// validationError membungkus daftar pelanggaran menjadi satu error, atau nil jika kosong
+func validationError(fields []apperror.FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	message := fields[0].Message
+	if len(fields) > 1 {
+		message = strconv.Itoa(len(fields)) + " fields are invalid"
+	}
+	err := apperror.Validation(message)
+	err.Fields = fields
+	return err
+}
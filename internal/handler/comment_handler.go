@@ -2,35 +2,49 @@ package handler
 
 import (
 	"strconv"
-	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/auth"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/metrics"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
 )
 
 // CommentHandler menangani HTTP requests untuk comments
 type CommentHandler struct {
-	repo        *repository.CommentRepository
-	projectRepo *repository.ProjectRepository
+	repo        repository.CommentStore
+	projectRepo repository.ProjectStore
+	admins      auth.AdminSet
 }
 
 // NewCommentHandler membuat instance baru dari CommentHandler
-func NewCommentHandler(repo *repository.CommentRepository, projectRepo *repository.ProjectRepository) *CommentHandler {
+func NewCommentHandler(repo repository.CommentStore, projectRepo repository.ProjectStore, admins auth.AdminSet) *CommentHandler {
 	return &CommentHandler{
 		repo:        repo,
 		projectRepo: projectRepo,
+		admins:      admins,
 	}
 }
 
 // GetCommentsByProjectID godoc
 // @Summary      Get project comments
-// @Description  Get all comments for a specific project
+// @Description  Get all comments for a specific project as a flat list (newest first), or as a tree with format=tree.
+// @Description  Comments hidden by a moderator are only included for the project creator and admins.
+// @Description  In tree mode, pass next_cursor or a comment's replies_cursor as cursor to load more top-level comments or replies
 // @Tags         Comments
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        id       path      string  true   "Project ID (numeric timestamped ID)"
+// @Param        format   query     string  false  "Response format (default flat)"  Enums(flat, tree)
+// @Param        sort     query     string  false  "Flat: new (default) or top (highest reaction score first)"  Enums(new, top)
+// @Param        limit    query     int     false  "Tree: number of top-level comments, or replies when cursor is a replies_cursor (default 20, max 100)"
+// @Param        replies  query     int     false  "Tree: replies included per comment at each level (default 3, max 50)"
+// @Param        depth    query     int     false  "Tree: number of levels to include (default and max 5)"
+// @Param        cursor   query     string  false  "Tree: next_cursor or replies_cursor from a previous response"
 // @Success      200  {array}   model.Comment
+// @Success      200  {object}  model.CommentTreeResponse
 // @Failure      400  {object}  model.ErrorResponse
 // @Failure      404  {object}  model.ErrorResponse
 // @Failure      500  {object}  model.ErrorResponse
@@ -39,45 +53,97 @@ func (h *CommentHandler) GetCommentsByProjectID(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	projectID, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
 	// Cek apakah proyek ada
-	project, err := h.projectRepo.GetByID(projectID)
+	project, err := h.projectRepo.WithContext(c.UserContext()).GetByID(projectID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to verify project",
-		})
+		return storeError(err, "Failed to verify project")
 	}
 
 	if project == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Project not found",
-		})
+		return repository.ErrProjectNotFound
+	}
+
+	params := repository.CommentListParams{
+		Sort:          c.Query("sort", repository.CommentSortNew),
+		Viewer:        auth.WalletFromCtx(c),
+		IncludeHidden: h.isModerator(c, project),
+	}
+	if params.Sort != repository.CommentSortNew && params.Sort != repository.CommentSortTop {
+		return problem.New(fiber.StatusBadRequest, "sort must be one of new, top")
+	}
+
+	switch c.Query("format", "flat") {
+	case "flat":
+	case "tree":
+		if params.Sort != repository.CommentSortNew {
+			return problem.New(fiber.StatusBadRequest, "sort=top is only supported for format=flat")
+		}
+		return h.getCommentTree(c, projectID, params.IncludeHidden)
+	default:
+		return problem.New(fiber.StatusBadRequest, "format must be one of flat, tree")
 	}
 
-	comments, err := h.repo.GetByProjectID(projectID)
+	comments, err := h.repo.WithContext(c.UserContext()).GetByProjectID(projectID, params)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch comments",
-		})
+		return storeError(err, "Failed to fetch comments")
 	}
 
 	return c.JSON(comments)
 }
 
+// getCommentTree menangani GET /projects/:id/comments?format=tree
+func (h *CommentHandler) getCommentTree(c *fiber.Ctx, projectID uint64, includeHidden bool) error {
+	params := repository.CommentTreeParams{
+		ProjectID:       projectID,
+		Limit:           c.QueryInt("limit", repository.DefaultCommentPageSize),
+		RepliesPerLevel: c.QueryInt("replies", repository.DefaultRepliesPerLevel),
+		MaxDepth:        c.QueryInt("depth", repository.MaxCommentDepth),
+		Cursor:          c.Query("cursor"),
+		Viewer:          auth.WalletFromCtx(c),
+		IncludeHidden:   includeHidden,
+	}
+
+	if params.Limit < 1 || params.Limit > repository.MaxCommentPageSize {
+		return problem.New(fiber.StatusBadRequest, "limit must be between 1 and 100")
+	}
+
+	if params.RepliesPerLevel < 1 || params.RepliesPerLevel > repository.MaxRepliesPerLevel {
+		return problem.New(fiber.StatusBadRequest, "replies must be between 1 and 50")
+	}
+
+	if params.MaxDepth < 1 || params.MaxDepth > repository.MaxCommentDepth {
+		return problem.New(fiber.StatusBadRequest, "depth must be between 1 and 5")
+	}
+
+	page, err := h.repo.WithContext(c.UserContext()).GetTree(params)
+	if err != nil {
+		return storeError(err, "Failed to fetch comments")
+	}
+
+	return c.JSON(fiber.Map{
+		"data":        page.Comments,
+		"next_cursor": page.NextCursor,
+	})
+}
+
 // CreateComment godoc
 // @Summary      Create comment
-// @Description  Add a new comment to a project. Supports nested comments via parent_comment_id
+// @Description  Add a new comment to a project. Supports nested comments via parent_comment_id, up to 5 levels deep.
+// @Description  author_wallet_address must be the authenticated wallet, and the author must sign (personal_sign) the text
+// @Description  "Web3 Crowdfunding Comment\nProject ID: <id>\nParent ID: <parent id or none>\nContent:\n<content>"
 // @Tags         Comments
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id       path      string         true  "Project ID (numeric timestamped ID)"
 // @Param        comment  body      model.CommentCreate  true  "Comment data"
 // @Success      201      {object}  model.Comment
 // @Failure      400      {object}  model.ErrorResponse
+// @Failure      401      {object}  model.ErrorResponse
+// @Failure      403      {object}  model.ErrorResponse
 // @Failure      404      {object}  model.ErrorResponse
 // @Failure      500      {object}  model.ErrorResponse
 // @Router       /projects/{id}/comments [post]
@@ -85,78 +151,669 @@ func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	projectID, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
 	// Cek apakah proyek ada
-	project, err := h.projectRepo.GetByID(projectID)
+	project, err := h.projectRepo.WithContext(c.UserContext()).GetByID(projectID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to verify project",
-		})
+		return storeError(err, "Failed to verify project")
 	}
 
 	if project == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Project not found",
-		})
+		return repository.ErrProjectNotFound
 	}
 
-	var comment model.Comment
-	if err := c.BodyParser(&comment); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	var body model.CommentCreate
+	if err := bind(c, &body); err != nil {
+		return err
 	}
 
-	// Set project ID dari URL param
-	comment.ProjectID = projectID
-
-	// Validasi field yang wajib diisi
-	if strings.TrimSpace(comment.AuthorWalletAddress) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "author_wallet_address is required",
-		})
+	// Signature saja bisa diputar ulang (terlihat di /verification dan /history), jadi author
+	// juga harus wallet dari session
+	if !auth.SameAddress(body.AuthorWalletAddress, auth.WalletFromCtx(c)) {
+		return apperror.Forbidden("author_wallet_address must be the authenticated wallet")
 	}
 
-	if strings.TrimSpace(comment.Content) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "content is required",
-		})
+	// Project ID diambil dari URL param
+	comment := model.Comment{
+		ProjectID:           projectID,
+		AuthorWalletAddress: body.AuthorWalletAddress,
+		Content:             body.Content,
+		ParentCommentID:     body.ParentCommentID,
+		Signature:           body.Signature,
 	}
 
 	// Jika ada parent comment, validasi bahwa parent comment ada
 	if comment.ParentCommentID != nil {
-		parentComment, err := h.repo.GetByID(*comment.ParentCommentID)
+		parentComment, err := h.repo.WithContext(c.UserContext()).GetByID(*comment.ParentCommentID)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to verify parent comment",
-			})
+			return storeError(err, "Failed to verify parent comment")
 		}
 
-		if parentComment == nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Parent comment not found",
-			})
+		// Komentar yang disembunyikan moderator tidak bisa dibalas
+		if parentComment == nil || parentComment.HiddenAt != nil {
+			return problem.New(fiber.StatusBadRequest, "Parent comment not found")
 		}
 
 		// Pastikan parent comment juga untuk proyek yang sama
 		if parentComment.ProjectID != projectID {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Parent comment does not belong to this project",
-			})
+			return problem.New(fiber.StatusBadRequest, "Parent comment does not belong to this project")
+		}
+
+		// Batasi kedalaman thread; balasan baru berada satu level di bawah parent
+		parentDepth, err := h.repo.WithContext(c.UserContext()).Depth(parentComment.ID)
+		if err != nil {
+			return storeError(err, "Failed to verify parent comment")
+		}
+		if parentDepth+1 >= repository.MaxCommentDepth {
+			return problem.New(fiber.StatusBadRequest, "Maximum reply depth reached; reply to a higher-level comment instead")
 		}
 	}
 
+	// Author hanya dipercaya jika signature atas project ID, parent ID dan content cocok
+	if err := auth.VerifyCommentSignature(projectID, comment.ParentCommentID, comment.Content, comment.AuthorWalletAddress, comment.Signature); err != nil {
+		return problem.New(fiber.StatusUnauthorized, "Signature does not match author_wallet_address")
+	}
+
 	// ID will be generated by BeforeCreate hook (numeric timestamped ID)
 
-	if err := h.repo.Create(&comment); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to create comment",
-		})
+	if err := h.repo.WithContext(c.UserContext()).Create(&comment); err != nil {
+		return storeError(err, "Failed to create comment")
 	}
+	metrics.CommentsPosted.Inc()
+	comment.Reactions = map[string]int64{}
 
 	return c.Status(fiber.StatusCreated).JSON(comment)
 }
+
+// UpdateComment godoc
+// @Summary      Edit comment
+// @Description  Replace the content of a comment. Only the author (authenticated wallet) may edit, and the new content must be signed
+// @Description  with the same message format as CreateComment. The previous content is kept in the comment's history.
+// @Description  Returns 404 when the project is deleted or is a draft/review project the author can no longer see
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string              true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string              true  "Comment ID (numeric timestamped ID)"
+// @Param        comment    body      model.CommentUpdate  true  "New content and signature"
+// @Success      200        {object}  model.Comment
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      403        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      409        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/comments/{commentId} [patch]
+func (h *CommentHandler) UpdateComment(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	commentIDParam := c.Params("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid comment ID format")
+	}
+
+	var body model.CommentUpdate
+	if err := bind(c, &body); err != nil {
+		return err
+	}
+
+	comment, err := h.repo.WithContext(c.UserContext()).GetByID(commentID)
+	if err != nil {
+		return storeError(err, "Failed to fetch comment")
+	}
+
+	if comment == nil || comment.ProjectID != projectID {
+		return apperror.NotFound("Comment not found")
+	}
+
+	if !auth.SameAddress(comment.AuthorWalletAddress, auth.WalletFromCtx(c)) {
+		return apperror.Forbidden("Only the author can edit this comment")
+	}
+
+	if comment.DeletedAt != nil {
+		return apperror.Conflict("Deleted comments cannot be edited")
+	}
+
+	// Signature baru harus menutupi konten baru agar komentar tetap bisa diverifikasi ulang
+	if err := auth.VerifyCommentSignature(projectID, comment.ParentCommentID, body.Content, comment.AuthorWalletAddress, body.Signature); err != nil {
+		return problem.New(fiber.StatusUnauthorized, "Signature does not match author_wallet_address")
+	}
+
+	if err := h.repo.WithContext(c.UserContext()).Edit(comment, body.Content, body.Signature); err != nil {
+		return storeError(err, "Failed to update comment")
+	}
+
+	if err := h.repo.WithContext(c.UserContext()).AttachReactions([]*model.Comment{comment}, auth.WalletFromCtx(c)); err != nil {
+		return storeError(err, "Failed to fetch comment reactions")
+	}
+
+	return c.JSON(comment)
+}
+
+// DeleteComment godoc
+// @Summary      Delete comment
+// @Description  Delete a comment. Only the author (authenticated wallet) may delete. Comments that have replies are kept as a "[deleted]" placeholder so threads stay intact.
+// @Description  Returns 404 when the project is deleted or is a draft/review project the author can no longer see
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Success      200        {object}  model.GenericMessage
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      403        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/comments/{commentId} [delete]
+func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	commentIDParam := c.Params("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid comment ID format")
+	}
+
+	comment, err := h.repo.WithContext(c.UserContext()).GetByID(commentID)
+	if err != nil {
+		return storeError(err, "Failed to fetch comment")
+	}
+
+	if comment == nil || comment.ProjectID != projectID || comment.DeletedAt != nil {
+		return apperror.NotFound("Comment not found")
+	}
+
+	if !auth.SameAddress(comment.AuthorWalletAddress, auth.WalletFromCtx(c)) {
+		return apperror.Forbidden("Only the author can delete this comment")
+	}
+
+	if _, err := h.repo.WithContext(c.UserContext()).Delete(commentID); err != nil {
+		return storeError(err, "Failed to delete comment")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Comment deleted successfully",
+	})
+}
+
+// AddReaction godoc
+// @Summary      React to comment
+// @Description  Add a reaction from the authenticated wallet. Each wallet can react once per type; repeating a reaction is a no-op.
+// @Description  Comments hidden by a moderator cannot be reacted to (404)
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Param        type       path      string  true  "Reaction type"  Enums(upvote, like, heart, fire, laugh)
+// @Success      200        {object}  model.CommentReactionsResponse
+// @Success      201        {object}  model.CommentReactionsResponse
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/comments/{commentId}/reactions/{type} [put]
+func (h *CommentHandler) AddReaction(c *fiber.Ctx) error {
+	return h.setReaction(c, true)
+}
+
+// RemoveReaction godoc
+// @Summary      Remove comment reaction
+// @Description  Remove a reaction of the authenticated wallet
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Param        type       path      string  true  "Reaction type"  Enums(upvote, like, heart, fire, laugh)
+// @Success      200        {object}  model.CommentReactionsResponse
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/comments/{commentId}/reactions/{type} [delete]
+func (h *CommentHandler) RemoveReaction(c *fiber.Ctx) error {
+	return h.setReaction(c, false)
+}
+
+// setReaction menambahkan atau menghapus reaksi wallet yang sedang login dan
+// mengembalikan agregat reaksi terbaru komentar tersebut
+func (h *CommentHandler) setReaction(c *fiber.Ctx, add bool) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	commentIDParam := c.Params("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid comment ID format")
+	}
+
+	reactionType := c.Params("type")
+	if _, ok := model.CommentReactionWeights[reactionType]; !ok {
+		return problem.New(fiber.StatusBadRequest, "type must be one of upvote, like, heart, fire, laugh")
+	}
+
+	comment, err := h.repo.WithContext(c.UserContext()).GetByID(commentID)
+	if err != nil {
+		return storeError(err, "Failed to fetch comment")
+	}
+
+	// Komentar tersembunyi tidak bisa diberi atau dicabut reaksinya
+	if comment == nil || comment.ProjectID != projectID || comment.DeletedAt != nil || comment.HiddenAt != nil {
+		return apperror.NotFound("Comment not found")
+	}
+
+	wallet := auth.WalletFromCtx(c)
+	status := fiber.StatusOK
+	if add {
+		created, err := h.repo.WithContext(c.UserContext()).AddReaction(&model.CommentReaction{
+			CommentID:     commentID,
+			WalletAddress: wallet,
+			Type:          reactionType,
+		})
+		if err != nil {
+			return storeError(err, "Failed to add reaction")
+		}
+		if created {
+			status = fiber.StatusCreated
+		}
+	} else if _, err := h.repo.WithContext(c.UserContext()).RemoveReaction(commentID, wallet, reactionType); err != nil {
+		return storeError(err, "Failed to remove reaction")
+	}
+
+	if err := h.repo.WithContext(c.UserContext()).AttachReactions([]*model.Comment{comment}, wallet); err != nil {
+		return storeError(err, "Failed to fetch comment reactions")
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"comment_id":       comment.ID,
+		"reactions":        comment.Reactions,
+		"score":            comment.Score,
+		"viewer_reactions": comment.ViewerReactions,
+	})
+}
+
+// ReportComment godoc
+// @Summary      Report comment
+// @Description  Report a comment to the project's moderators with a reason. A wallet can have one open report per comment.
+// @Description  Comments hidden by a moderator cannot be reported (404)
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string                     true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string                     true  "Comment ID (numeric timestamped ID)"
+// @Param        report     body      model.CommentReportRequest  true  "Report reason"
+// @Success      201        {object}  model.CommentReport
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      409        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/comments/{commentId}/reports [post]
+func (h *CommentHandler) ReportComment(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	commentIDParam := c.Params("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid comment ID format")
+	}
+
+	var body model.CommentReportRequest
+	if err := bind(c, &body); err != nil {
+		return err
+	}
+
+	comment, err := h.repo.WithContext(c.UserContext()).GetByID(commentID)
+	if err != nil {
+		return storeError(err, "Failed to fetch comment")
+	}
+
+	// Komentar tersembunyi sudah ditangani moderator, jadi tidak bisa dilaporkan lagi
+	if comment == nil || comment.ProjectID != projectID || comment.DeletedAt != nil || comment.HiddenAt != nil {
+		return apperror.NotFound("Comment not found")
+	}
+
+	report := model.CommentReport{
+		CommentID:             commentID,
+		ReporterWalletAddress: auth.WalletFromCtx(c),
+		Reason:                body.Reason,
+	}
+	if err := h.repo.WithContext(c.UserContext()).Report(&report); err != nil {
+		return storeError(err, "Failed to report comment")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(report)
+}
+
+// GetModerationQueue godoc
+// @Summary      Get comment moderation queue
+// @Description  List comments with open reports, most reported first. The project-scoped route is available to the project creator and admins;
+// @Description  /moderation/comments lists every project and is admin-only
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string  true   "Project ID (numeric timestamped ID)"
+// @Param        limit   query     int     false  "Page size (default 20, max 100)"
+// @Param        offset  query     int     false  "Number of entries to skip"
+// @Success      200     {object}  model.ModerationQueueResponse
+// @Failure      400     {object}  model.ErrorResponse
+// @Failure      401     {object}  model.ErrorResponse
+// @Failure      403     {object}  model.ErrorResponse
+// @Failure      404     {object}  model.ErrorResponse
+// @Failure      500     {object}  model.ErrorResponse
+// @Router       /projects/{id}/moderation/comments [get]
+func (h *CommentHandler) GetModerationQueue(c *fiber.Ctx) error {
+	params := repository.ModerationQueueParams{
+		Limit:  c.QueryInt("limit", repository.DefaultCommentPageSize),
+		Offset: c.QueryInt("offset", 0),
+	}
+
+	if idParam := c.Params("id"); idParam != "" {
+		projectID, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+		}
+		params.ProjectID = &projectID
+	}
+
+	if params.Limit < 1 || params.Limit > repository.MaxCommentPageSize || params.Offset < 0 {
+		return problem.New(fiber.StatusBadRequest, "limit must be between 1 and 100 and offset must not be negative")
+	}
+
+	queue, total, err := h.repo.WithContext(c.UserContext()).ModerationQueue(params)
+	if err != nil {
+		return storeError(err, "Failed to fetch moderation queue")
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   queue,
+		"total":  total,
+		"limit":  params.Limit,
+		"offset": params.Offset,
+	})
+}
+
+// HideComment godoc
+// @Summary      Hide comment
+// @Description  Hide a comment from everyone except moderators and resolve its open reports. Project creator and admins only
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Success      200        {object}  model.GenericMessage
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      403        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/moderation/comments/{commentId}/hide [post]
+func (h *CommentHandler) HideComment(c *fiber.Ctx) error {
+	return h.moderateComment(c, "hide")
+}
+
+// UnhideComment godoc
+// @Summary      Unhide comment
+// @Description  Make a hidden comment visible again. Project creator and admins only
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Success      200        {object}  model.GenericMessage
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      403        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/moderation/comments/{commentId}/unhide [post]
+func (h *CommentHandler) UnhideComment(c *fiber.Ctx) error {
+	return h.moderateComment(c, "unhide")
+}
+
+// DismissCommentReports godoc
+// @Summary      Dismiss comment reports
+// @Description  Resolve a comment's open reports without hiding it. Project creator and admins only
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Success      200        {object}  model.GenericMessage
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      403        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/moderation/comments/{commentId}/dismiss [post]
+func (h *CommentHandler) DismissCommentReports(c *fiber.Ctx) error {
+	return h.moderateComment(c, "dismiss")
+}
+
+// ModeratorDeleteComment godoc
+// @Summary      Delete comment as moderator
+// @Description  Delete any comment of the project and resolve its open reports. Comments with replies are kept as a "[deleted]" placeholder.
+// @Description  Project creator and admins only
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Success      200        {object}  model.GenericMessage
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      401        {object}  model.ErrorResponse
+// @Failure      403        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/moderation/comments/{commentId} [delete]
+func (h *CommentHandler) ModeratorDeleteComment(c *fiber.Ctx) error {
+	return h.moderateComment(c, "delete")
+}
+
+// moderateComment menjalankan aksi moderasi (hide, unhide, dismiss, delete) pada komentar project.
+// Otorisasi dilakukan oleh middleware rute.
+func (h *CommentHandler) moderateComment(c *fiber.Ctx, action string) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	commentIDParam := c.Params("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid comment ID format")
+	}
+
+	comment, err := h.repo.WithContext(c.UserContext()).GetByID(commentID)
+	if err != nil {
+		return storeError(err, "Failed to fetch comment")
+	}
+
+	if comment == nil || comment.ProjectID != projectID {
+		return apperror.NotFound("Comment not found")
+	}
+
+	moderator := auth.WalletFromCtx(c)
+	var message string
+	switch action {
+	case "hide":
+		message = "Comment hidden successfully"
+		err = h.repo.WithContext(c.UserContext()).SetHidden(commentID, true, moderator)
+	case "unhide":
+		message = "Comment unhidden successfully"
+		err = h.repo.WithContext(c.UserContext()).SetHidden(commentID, false, moderator)
+	case "dismiss":
+		message = "Reports dismissed successfully"
+		err = h.repo.WithContext(c.UserContext()).ResolveReports(commentID, model.CommentReportResolutionDismissed, moderator)
+	case "delete":
+		message = "Comment deleted successfully"
+		if err = h.repo.WithContext(c.UserContext()).ResolveReports(commentID, model.CommentReportResolutionDeleted, moderator); err == nil {
+			_, err = h.repo.WithContext(c.UserContext()).Delete(commentID)
+		}
+	}
+	if err != nil {
+		return storeError(err, "Failed to moderate comment")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": message,
+	})
+}
+
+// isModerator mengecek apakah wallet yang sedang login boleh memoderasi komentar project
+// (creator project atau admin)
+func (h *CommentHandler) isModerator(c *fiber.Ctx, project *model.Project) bool {
+	wallet := auth.WalletFromCtx(c)
+	return auth.SameAddress(project.CreatorWalletAddress, wallet) || h.admins.Contains(wallet)
+}
+
+// canViewComment mengecek apakah wallet yang sedang login boleh melihat komentar; komentar
+// yang disembunyikan moderator hanya terlihat oleh creator project dan admin, sama seperti
+// pada daftar komentar
+func (h *CommentHandler) canViewComment(c *fiber.Ctx, comment *model.Comment) (bool, error) {
+	if comment.HiddenAt == nil {
+		return true, nil
+	}
+
+	project, err := h.projectRepo.WithContext(c.UserContext()).GetByID(comment.ProjectID)
+	if err != nil {
+		return false, err
+	}
+	return project != nil && h.isModerator(c, project), nil
+}
+
+// GetCommentHistory godoc
+// @Summary      Get comment edit history
+// @Description  List the previous revisions of an edited comment, oldest first.
+// @Description  Comments hidden by a moderator return 404 unless the caller is the project creator or an admin
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Success      200        {array}   model.CommentRevision
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/comments/{commentId}/history [get]
+func (h *CommentHandler) GetCommentHistory(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	commentIDParam := c.Params("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid comment ID format")
+	}
+
+	comment, err := h.repo.WithContext(c.UserContext()).GetByID(commentID)
+	if err != nil {
+		return storeError(err, "Failed to fetch comment")
+	}
+
+	if comment == nil || comment.ProjectID != projectID {
+		return apperror.NotFound("Comment not found")
+	}
+
+	if visible, err := h.canViewComment(c, comment); err != nil {
+		return storeError(err, "Failed to verify project")
+	} else if !visible {
+		return apperror.NotFound("Comment not found")
+	}
+
+	revisions, err := h.repo.WithContext(c.UserContext()).GetRevisions(commentID)
+	if err != nil {
+		return storeError(err, "Failed to fetch comment history")
+	}
+
+	return c.JSON(revisions)
+}
+
+// VerifyComment godoc
+// @Summary      Verify comment signature
+// @Description  Rebuild the signed message of a stored comment and re-verify its author signature.
+// @Description  Comments hidden by a moderator return 404 unless the caller is the project creator or an admin
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        commentId  path      string  true  "Comment ID (numeric timestamped ID)"
+// @Success      200        {object}  model.CommentSignatureVerification
+// @Failure      400        {object}  model.ErrorResponse
+// @Failure      404        {object}  model.ErrorResponse
+// @Failure      500        {object}  model.ErrorResponse
+// @Router       /projects/{id}/comments/{commentId}/verification [get]
+func (h *CommentHandler) VerifyComment(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	commentIDParam := c.Params("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid comment ID format")
+	}
+
+	comment, err := h.repo.WithContext(c.UserContext()).GetByID(commentID)
+	if err != nil {
+		return storeError(err, "Failed to fetch comment")
+	}
+
+	if comment == nil || comment.ProjectID != projectID {
+		return apperror.NotFound("Comment not found")
+	}
+
+	if visible, err := h.canViewComment(c, comment); err != nil {
+		return storeError(err, "Failed to verify project")
+	} else if !visible {
+		return apperror.NotFound("Comment not found")
+	}
+
+	valid := comment.Signature != "" && auth.VerifyCommentSignature(
+		comment.ProjectID, comment.ParentCommentID, comment.Content, comment.AuthorWalletAddress, comment.Signature,
+	) == nil
+
+	return c.JSON(fiber.Map{
+		"comment_id":            comment.ID,
+		"author_wallet_address": comment.AuthorWalletAddress,
+		"signed_message":        auth.CommentSigningMessage(comment.ProjectID, comment.ParentCommentID, comment.Content),
+		"signature":             comment.Signature,
+		"valid":                 valid,
+	})
+}
@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+)
+
+// storeError meneruskan error domain dari repository apa adanya (pesannya sudah untuk client
+// dan ErrorHandler yang memetakannya ke status HTTP); error lain menjadi 500 dengan detail
+func storeError(err error, detail string) error {
+	if apperror.As(err) != nil {
+		return err
+	}
+	return problem.New(fiber.StatusInternalServerError, detail)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+)
+
+// projectETag membuat ETag (strong) dari versi project untuk If-Match. Versi naik setiap kali
+// field project atau external link-nya berubah; field turunan seperti funding_progress tidak
+// ikut dihitung, sehingga investasi baru tidak membatalkan edit yang sedang berjalan.
+func projectETag(project *model.Project) string {
+	return `"` + strconv.FormatUint(project.Version, 10) + `"`
+}
+
+// projectResponseETag membuat ETag (weak) untuk GET /projects/{id}: versi project ditambah hash
+// field yang diturunkan dari ledger (investor dan funding_progress), agar If-None-Match tidak
+// menghasilkan 304 ketika hanya pendanaannya yang berubah
+func projectResponseETag(project *model.Project) string {
+	h := fnv.New64a()
+	_ = json.NewEncoder(h).Encode(struct {
+		Investors model.StringArray
+		Funding   *model.FundingProgress
+	}{project.InvestorWalletAddresses, project.FundingProgress})
+	return `W/"` + strconv.FormatUint(project.Version, 10) + "-" + strconv.FormatUint(h.Sum64(), 16) + `"`
+}
+
+// etagMatches mengecek apakah daftar ETag di header (If-Match / If-None-Match) memuat etag atau "*".
+// If-Match memakai perbandingan strong (ETag weak "W/..." tidak pernah cocok), If-None-Match
+// memakai perbandingan weak.
+func etagMatches(header, etag string, weak bool) bool {
+	if weak {
+		etag = strings.TrimPrefix(etag, "W/")
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch mewajibkan request yang mengubah project membawa If-Match berisi ETag project
+// saat ini, agar dua editor tidak saling menimpa perubahan tanpa sadar
+func checkIfMatch(c *fiber.Ctx, project *model.Project) error {
+	header := c.Get(fiber.HeaderIfMatch)
+	if header == "" {
+		return problem.New(fiber.StatusPreconditionRequired, "If-Match header is required; send the project version as a quoted ETag, e.g. \"3\"")
+	}
+
+	etag := projectETag(project)
+	if !etagMatches(header, etag, false) {
+		c.Set(fiber.HeaderETag, etag)
+		return apperror.PreconditionFailed("Project has been modified since it was last read").
+			With("current_version", project.Version)
+	}
+	return nil
+}
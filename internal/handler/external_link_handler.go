@@ -5,27 +5,71 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
 )
 
 // ExternalLinkHandler menangani HTTP requests untuk external links
 type ExternalLinkHandler struct {
-	repo        *repository.ExternalLinkRepository
-	projectRepo *repository.ProjectRepository
+	repo        repository.ExternalLinkStore
+	projectRepo repository.ProjectStore
 }
 
 // NewExternalLinkHandler membuat instance baru dari ExternalLinkHandler
-func NewExternalLinkHandler(repo *repository.ExternalLinkRepository, projectRepo *repository.ProjectRepository) *ExternalLinkHandler {
+func NewExternalLinkHandler(repo repository.ExternalLinkStore, projectRepo repository.ProjectStore) *ExternalLinkHandler {
 	return &ExternalLinkHandler{
 		repo:        repo,
 		projectRepo: projectRepo,
 	}
 }
 
+// externalLinkPatch adalah body update external link; field nil tidak diubah.
+// Aturannya sama dengan model.ExternalLinkCreate.
+type externalLinkPatch struct {
+	Name     *string `json:"name" validate:"nonblank,max=50"`
+	Type     *string `json:"type" validate:"omitempty,oneof=website twitter discord telegram steam epic itchio youtube twitch instagram tiktok facebook reddit github whitepaper other"`
+	URL      *string `json:"url" validate:"nonblank,max=500,url"`
+	Position *int    `json:"position" validate:"min=0"`
+}
+
+// Normalize merapikan field yang dikirim seperti model.ExternalLinkCreate.Normalize
+func (p *externalLinkPatch) Normalize() {
+	trim := func(s *string) {
+		if s != nil {
+			*s = strings.TrimSpace(*s)
+		}
+	}
+	trim(p.Name)
+	trim(p.URL)
+	if p.Type != nil {
+		*p.Type = strings.ToLower(strings.TrimSpace(*p.Type))
+	}
+}
+
+// apply menyalin field yang dikirim client ke link
+func (p externalLinkPatch) apply(link *model.ExternalLink) {
+	if p.Name != nil {
+		link.Name = *p.Name
+	}
+	if p.Type != nil {
+		link.Type = *p.Type
+		if link.Type == "" {
+			link.Type = "other"
+		}
+	}
+	if p.URL != nil {
+		link.URL = *p.URL
+	}
+	if p.Position != nil {
+		link.Position = *p.Position
+	}
+}
+
 // GetLinksByProjectID godoc
 // @Summary      Get project external links
-// @Description  Get all external links for a specific project
+// @Description  Get all external links for a specific project, ordered by position
 // @Tags         External Links
 // @Accept       json
 // @Produce      json
@@ -39,30 +83,22 @@ func (h *ExternalLinkHandler) GetLinksByProjectID(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	projectID, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
 	// Cek apakah proyek ada
-	project, err := h.projectRepo.GetByID(projectID)
+	project, err := h.projectRepo.WithContext(c.UserContext()).GetByID(projectID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to verify project",
-		})
+		return storeError(err, "Failed to verify project")
 	}
 
 	if project == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Project not found",
-		})
+		return repository.ErrProjectNotFound
 	}
 
-	links, err := h.repo.GetByProjectID(projectID)
+	links, err := h.repo.WithContext(c.UserContext()).GetByProjectID(projectID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch external links",
-		})
+		return storeError(err, "Failed to fetch external links")
 	}
 
 	return c.JSON(links)
@@ -70,69 +106,45 @@ func (h *ExternalLinkHandler) GetLinksByProjectID(c *fiber.Ctx) error {
 
 // CreateLink godoc
 // @Summary      Create external link
-// @Description  Add a new external link to a project (e.g., Instagram, Twitter, Website)
+// @Description  Add a new external link to a project. url must be an absolute http(s) URL of at most 500 characters; type defaults to "other" and position defaults to the end of the list
 // @Tags         External Links
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id    path      string              true  "Project ID (numeric timestamped ID)"
 // @Param        link  body      model.ExternalLinkCreate  true  "External link data"
 // @Success      201   {object}  model.ExternalLink
 // @Failure      400   {object}  model.ErrorResponse
+// @Failure      401   {object}  model.ErrorResponse
+// @Failure      403   {object}  model.ErrorResponse
 // @Failure      404   {object}  model.ErrorResponse
 // @Failure      500   {object}  model.ErrorResponse
 // @Router       /projects/{id}/links [post]
 func (h *ExternalLinkHandler) CreateLink(c *fiber.Ctx) error {
-	idParam := c.Params("id")
-	projectID, err := strconv.ParseUint(idParam, 10, 64)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
-	}
-
-	// Cek apakah proyek ada
-	project, err := h.projectRepo.GetByID(projectID)
+	// Keberadaan project dan kepemilikannya sudah diperiksa oleh RequireProjectOwner
+	projectID, err := strconv.ParseUint(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to verify project",
-		})
-	}
-
-	if project == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Project not found",
-		})
-	}
-
-	var link model.ExternalLink
-	if err := c.BodyParser(&link); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
-	// Set project ID dari URL param
-	link.ProjectID = projectID
-
-	// Validasi field yang wajib diisi
-	if strings.TrimSpace(link.Name) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "name is required",
-		})
+	var body model.ExternalLinkCreate
+	if err := bind(c, &body); err != nil {
+		return err
 	}
 
-	if strings.TrimSpace(link.URL) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "url is required",
-		})
+	link := body.ExternalLink(projectID)
+	if body.Position == nil {
+		next, err := h.repo.WithContext(c.UserContext()).NextPosition(projectID)
+		if err != nil {
+			return storeError(err, "Failed to create external link")
+		}
+		link.Position = next
 	}
 
 	// ID will be generated by BeforeCreate hook (numeric timestamped ID)
 
-	if err := h.repo.Create(&link); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to create external link",
-		})
+	if err := h.repo.WithContext(c.UserContext()).Create(&link); err != nil {
+		return storeError(err, "Failed to create external link")
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(link)
@@ -140,59 +152,35 @@ func (h *ExternalLinkHandler) CreateLink(c *fiber.Ctx) error {
 
 // UpdateLink godoc
 // @Summary      Update external link
-// @Description  Update an external link
+// @Description  Update an external link. Only the fields present in the body are changed; the same validation as create applies
 // @Tags         External Links
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id      path      string              true  "Project ID (numeric timestamped ID)"
-// @Param        linkId  path      string              true  "Link ID (UUID v7)"
+// @Param        linkId  path      string              true  "Link ID (numeric timestamped ID)"
 // @Param        link    body      model.ExternalLinkCreate  true  "External link data"
 // @Success      200     {object}  model.ExternalLink
 // @Failure      400     {object}  model.ErrorResponse
+// @Failure      401     {object}  model.ErrorResponse
+// @Failure      403     {object}  model.ErrorResponse
 // @Failure      404     {object}  model.ErrorResponse
 // @Failure      500     {object}  model.ErrorResponse
 // @Router       /projects/{id}/links/{linkId} [put]
 func (h *ExternalLinkHandler) UpdateLink(c *fiber.Ctx) error {
-	linkIDParam := c.Params("linkId")
-	linkID, err := strconv.ParseUint(linkIDParam, 10, 64)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid link ID format",
-		})
-	}
-
-	existingLink, err := h.repo.GetByID(linkID)
+	existingLink, err := h.loadProjectLink(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch link",
-		})
-	}
-
-	if existingLink == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "External link not found",
-		})
+		return err
 	}
 
-	var updates model.ExternalLink
-	if err := c.BodyParser(&updates); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	var body externalLinkPatch
+	if err := bind(c, &body); err != nil {
+		return err
 	}
+	body.apply(existingLink)
 
-	// Update only allowed fields
-	if strings.TrimSpace(updates.Name) != "" {
-		existingLink.Name = updates.Name
-	}
-	if strings.TrimSpace(updates.URL) != "" {
-		existingLink.URL = updates.URL
-	}
-
-	if err := h.repo.Update(existingLink); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update external link",
-		})
+	if err := h.repo.WithContext(c.UserContext()).Update(existingLink); err != nil {
+		return storeError(err, "Failed to update external link")
 	}
 
 	return c.JSON(existingLink)
@@ -204,42 +192,52 @@ func (h *ExternalLinkHandler) UpdateLink(c *fiber.Ctx) error {
 // @Tags         External Links
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id      path      string  true  "Project ID (numeric timestamped ID)"
-// @Param        linkId  path      string  true  "Link ID (UUID v7)"
+// @Param        linkId  path      string  true  "Link ID (numeric timestamped ID)"
 // @Success      200     {object}  model.GenericMessage
 // @Failure      400     {object}  model.ErrorResponse
+// @Failure      401     {object}  model.ErrorResponse
+// @Failure      403     {object}  model.ErrorResponse
 // @Failure      404     {object}  model.ErrorResponse
 // @Failure      500     {object}  model.ErrorResponse
 // @Router       /projects/{id}/links/{linkId} [delete]
 func (h *ExternalLinkHandler) DeleteLink(c *fiber.Ctx) error {
-	linkIDParam := c.Params("linkId")
-	linkID, err := strconv.ParseUint(linkIDParam, 10, 64)
+	existingLink, err := h.loadProjectLink(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid link ID format",
-		})
+		return err
 	}
 
-	existingLink, err := h.repo.GetByID(linkID)
+	if err := h.repo.WithContext(c.UserContext()).Delete(existingLink.ID); err != nil {
+		return storeError(err, "Failed to delete external link")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "External link deleted successfully",
+	})
+}
+
+// loadProjectLink mengambil link dari parameter ":linkId" dan memastikan link tersebut milik
+// project ":id". Link milik project lain dilaporkan sebagai tidak ditemukan.
+func (h *ExternalLinkHandler) loadProjectLink(c *fiber.Ctx) (*model.ExternalLink, error) {
+	projectID, err := strconv.ParseUint(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch link",
-		})
+		return nil, problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
-	if existingLink == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "External link not found",
-		})
+	linkID, err := strconv.ParseUint(c.Params("linkId"), 10, 64)
+	if err != nil {
+		return nil, problem.New(fiber.StatusBadRequest, "Invalid link ID format")
 	}
 
-	if err := h.repo.Delete(linkID); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to delete external link",
-		})
+	link, err := h.repo.WithContext(c.UserContext()).GetByID(linkID)
+	if err != nil {
+		return nil, storeError(err, "Failed to fetch link")
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "External link deleted successfully",
-	})
+	if link == nil || link.ProjectID != projectID {
+		return nil, apperror.NotFound("External link not found")
+	}
+
+	return link, nil
 }
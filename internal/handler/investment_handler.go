@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/metrics"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// amountPattern menerima bilangan bulat non-negatif sampai 78 digit (muat uint256)
+var amountPattern = regexp.MustCompile(`^[0-9]{1,78}$`)
+
+// onChainInvestmentFields hanya diisi indexer dari event kontrak. Entri manual yang membawanya
+// bisa merebut key (chain_id, tx_hash, log_index) milik event asli, sehingga ditolak.
+var onChainInvestmentFields = []string{"chain_id", "tx_hash", "log_index", "block_number"}
+
+// InvestmentHandler menangani HTTP requests untuk ledger investasi
+type InvestmentHandler struct {
+	repo        repository.InvestmentStore
+	projectRepo repository.ProjectStore
+}
+
+// NewInvestmentHandler membuat instance baru dari InvestmentHandler
+func NewInvestmentHandler(repo repository.InvestmentStore, projectRepo repository.ProjectStore) *InvestmentHandler {
+	return &InvestmentHandler{
+		repo:        repo,
+		projectRepo: projectRepo,
+	}
+}
+
+// GetInvestments godoc
+// @Summary      Get project investments
+// @Description  List the investment ledger of a project, newest first
+// @Tags         Investments
+// @Accept       json
+// @Produce      json
+// @Param        id              path      string  true   "Project ID (numeric timestamped ID)"
+// @Param        wallet_address  query     string  false  "Only investments from this wallet"
+// @Param        limit           query     int     false  "Page size (default 20, max 100)"
+// @Param        offset          query     int     false  "Number of entries to skip"
+// @Success      200  {object}  model.InvestmentListResponse
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/investments [get]
+func (h *InvestmentHandler) GetInvestments(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	params := repository.InvestmentListParams{
+		WalletAddress: c.Query("wallet_address"),
+		Limit:         c.QueryInt("limit", repository.DefaultProjectPageSize),
+		Offset:        c.QueryInt("offset", 0),
+	}
+
+	if params.Limit < 1 || params.Limit > repository.MaxProjectPageSize || params.Offset < 0 {
+		return problem.New(fiber.StatusBadRequest, "limit must be between 1 and 100 and offset must not be negative")
+	}
+
+	// Cek apakah proyek ada
+	project, err := h.projectRepo.WithContext(c.UserContext()).GetByID(projectID)
+	if err != nil {
+		return storeError(err, "Failed to verify project")
+	}
+
+	if project == nil {
+		return repository.ErrProjectNotFound
+	}
+
+	investments, total, err := h.repo.WithContext(c.UserContext()).ListByProject(projectID, params)
+	if err != nil {
+		return storeError(err, "Failed to fetch investments")
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   investments,
+		"total":  total,
+		"limit":  params.Limit,
+		"offset": params.Offset,
+	})
+}
+
+// GetInvestmentSummary godoc
+// @Summary      Get project investment summary
+// @Description  Aggregate the investment ledger of a project: distinct investors, number of investments and totals per chain/token
+// @Tags         Investments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Success      200  {object}  model.InvestmentSummary
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/investments/summary [get]
+func (h *InvestmentHandler) GetInvestmentSummary(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	// Cek apakah proyek ada
+	project, err := h.projectRepo.WithContext(c.UserContext()).GetByID(projectID)
+	if err != nil {
+		return storeError(err, "Failed to verify project")
+	}
+
+	if project == nil {
+		return repository.ErrProjectNotFound
+	}
+
+	summary, err := h.repo.WithContext(c.UserContext()).Summary(projectID)
+	if err != nil {
+		return storeError(err, "Failed to summarize investments")
+	}
+
+	return c.JSON(summary)
+}
+
+// CreateInvestment godoc
+// @Summary      Record investment
+// @Description  Record a manual (off-chain) investment in the project ledger. Only the project creator or co-owners may record investments manually.
+// @Description  On-chain fields (chain_id, tx_hash, log_index, block_number) are rejected; they are recorded by the indexer only. Manual entries are listed
+// @Description  with source "manual" and do not count toward funding_progress or the funded/failed settlement
+// @Tags         Investments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      string                        true  "Project ID (numeric timestamped ID)"
+// @Param        investment  body      model.InvestmentCreateRequest  true  "Investment data"
+// @Success      201  {object}  model.Investment
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      409  {object}  model.ErrorResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/investments [post]
+func (h *InvestmentHandler) CreateInvestment(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	projectID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	var body model.InvestmentCreateRequest
+	if err := bind(c, &body); err != nil {
+		return err
+	}
+
+	// Project ID diambil dari URL param
+	investment := model.Investment{
+		ProjectID:     projectID,
+		WalletAddress: body.WalletAddress,
+		Kind:          body.Kind,
+		Source:        model.InvestmentSourceManual,
+		Amount:        body.Amount,
+		TokenAddress:  body.TokenAddress,
+		InvestedAt:    body.InvestedAt,
+	}
+
+	if investment.Kind == "" {
+		investment.Kind = model.InvestmentKindInvestment
+	}
+
+	// Format angka dan hash tidak bisa dinyatakan dengan tag validate
+	var fields []apperror.FieldError
+	if !amountPattern.MatchString(investment.Amount) {
+		fields = append(fields, apperror.FieldError{Field: "amount", Message: "amount must be a non-negative integer in the token's smallest unit (wei)"})
+	}
+	var raw map[string]json.RawMessage
+	_ = json.Unmarshal(c.Body(), &raw)
+	for _, field := range onChainInvestmentFields {
+		if _, ok := raw[field]; ok {
+			fields = append(fields, apperror.FieldError{Field: field, Message: field + " is recorded by the on-chain indexer and cannot be set on manual entries"})
+		}
+	}
+	if err := validationError(fields); err != nil {
+		return err
+	}
+
+	if investment.InvestedAt.IsZero() {
+		investment.InvestedAt = time.Now()
+	}
+
+	if err := h.repo.WithContext(c.UserContext()).Create(&investment); err != nil {
+		return storeError(err, "Failed to record investment")
+	}
+	metrics.InvestmentsRecorded.WithLabelValues(investment.Source).Inc()
+
+	return c.Status(fiber.StatusCreated).JSON(investment)
+}
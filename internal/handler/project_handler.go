@@ -1,52 +1,175 @@
 package handler
 
 import (
+	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/auth"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/metrics"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
 )
 
 // ProjectHandler menangani HTTP requests untuk projects
 type ProjectHandler struct {
-	repo *repository.ProjectRepository
+	repo repository.ProjectStore
 }
 
 // NewProjectHandler membuat instance baru dari ProjectHandler
-func NewProjectHandler(repo *repository.ProjectRepository) *ProjectHandler {
+func NewProjectHandler(repo repository.ProjectStore) *ProjectHandler {
 	return &ProjectHandler{repo: repo}
 }
 
 // GetAllProjects godoc
 // @Summary      Get all projects
-// @Description  Retrieve list of all crowdfunding projects
+// @Description  Retrieve a page of crowdfunding projects. Uses cursor-based pagination that is stable on (sort key, id).
+// @Description  investor_count is computed live and can change between requests, so that sort returns only the top page: next_cursor is always empty and cursor is rejected
 // @Tags         Projects
 // @Accept       json
 // @Produce      json
-// @Success      200  {array}   model.ProjectSwagger
+// @Param        limit                   query     int     false  "Page size (default 20, max 100)"
+// @Param        cursor                  query     string  false  "next_cursor from the previous page"
+// @Param        sort                    query     string  false  "Sort key, prefix with - for descending (default -created_at)"  Enums(created_at, -created_at, updated_at, -updated_at, title, -title, investor_count, -investor_count)
+// @Param        genre                   query     string  false  "Filter by genre (case-insensitive)"
+// @Param        game_type               query     string  false  "Filter by game type (case-insensitive)"
+// @Param        creator_wallet_address  query     string  false  "Filter by creator wallet address"
+// @Param        developer_name          query     string  false  "Filter by developer name (partial match)"
+// @Param        status                  query     string  false  "Comma-separated statuses (default live,funded,failed). draft and review require authentication and only include projects the wallet created or co-owns"
+// @Success      200  {object}  model.ProjectListResponse
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
 // @Failure      500  {object}  model.ErrorResponse
 // @Router       /projects [get]
 func (h *ProjectHandler) GetAllProjects(c *fiber.Ctx) error {
-	projects, err := h.repo.GetAll()
+	params := repository.ProjectListParams{
+		Limit:                c.QueryInt("limit", repository.DefaultProjectPageSize),
+		Cursor:               c.Query("cursor"),
+		Genre:                c.Query("genre"),
+		GameType:             c.Query("game_type"),
+		CreatorWalletAddress: c.Query("creator_wallet_address"),
+		DeveloperName:        c.Query("developer_name"),
+		ViewerWalletAddress:  auth.WalletFromCtx(c),
+	}
+
+	if params.Limit < 1 || params.Limit > repository.MaxProjectPageSize {
+		return problem.New(fiber.StatusBadRequest, "limit must be between 1 and 100")
+	}
+
+	sort := c.Query("sort", "-created_at")
+	params.Sort, params.Desc = strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+	if !repository.ValidProjectSort(params.Sort) {
+		return problem.New(fiber.StatusBadRequest, "sort must be one of created_at, updated_at, title, investor_count (prefix with - for descending)")
+	}
+	if params.Cursor != "" && !repository.ProjectSortHasCursor(params.Sort) {
+		return apperror.Validation("cursor is not supported when sorting by "+params.Sort).WithField("cursor", "cursor is not supported when sorting by "+params.Sort)
+	}
+
+	params.Statuses = model.DefaultPublicProjectStatuses
+	if raw := strings.TrimSpace(c.Query("status")); raw != "" {
+		params.Statuses = nil
+		for _, status := range strings.Split(raw, ",") {
+			status = strings.ToLower(strings.TrimSpace(status))
+			if !model.ValidProjectStatus(status) {
+				return problem.New(fiber.StatusBadRequest, "status must be a comma-separated list of draft, review, live, funded, failed, archived")
+			}
+			// Draft dan review hanya boleh dilihat pemiliknya (creator atau co-owner); repository
+			// menyaring project yang bukan milik ViewerWalletAddress
+			if !model.ProjectStatusIsPublic(status) && params.ViewerWalletAddress == "" {
+				return apperror.Forbidden("draft and review projects can only be listed by an authenticated owner").WithField("status", "draft and review projects can only be listed by an authenticated owner")
+			}
+			params.Statuses = append(params.Statuses, status)
+		}
+	}
+
+	page, err := h.repo.WithContext(c.UserContext()).List(params)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch projects",
-		})
+		return storeError(err, "Failed to fetch projects")
 	}
 
-	return c.JSON(projects)
+	return c.JSON(fiber.Map{
+		"data":        page.Projects,
+		"next_cursor": page.NextCursor,
+		"total":       page.Total,
+		"limit":       params.Limit,
+	})
+}
+
+// SearchProjects godoc
+// @Summary      Search projects
+// @Description  Full-text search over title, description, developer name and genre of live, funded and failed projects, ranked by relevance with highlighted snippets
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Param        q       query     string  true   "Search keywords (websearch syntax: quoted phrases, OR, -exclude)"
+// @Param        lang    query     string  false  "Stemming language; both are used when omitted"  Enums(en, id)
+// @Param        limit   query     int     false  "Page size (default 20, max 100)"
+// @Param        offset  query     int     false  "Number of results to skip"
+// @Success      200  {object}  model.ProjectSearchResponse
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/search [get]
+func (h *ProjectHandler) SearchProjects(c *fiber.Ctx) error {
+	params := repository.ProjectSearchParams{
+		Query:    strings.TrimSpace(c.Query("q")),
+		Language: c.Query("lang"),
+		Limit:    c.QueryInt("limit", repository.DefaultProjectPageSize),
+		Offset:   c.QueryInt("offset", 0),
+		Statuses: model.DefaultPublicProjectStatuses,
+	}
+
+	if params.Query == "" {
+		return problem.New(fiber.StatusBadRequest, "q is required")
+	}
+
+	if _, ok := repository.SearchLanguages[params.Language]; params.Language != "" && !ok {
+		return problem.New(fiber.StatusBadRequest, "lang must be one of en, id")
+	}
+
+	if params.Limit < 1 || params.Limit > repository.MaxProjectPageSize || params.Offset < 0 {
+		return problem.New(fiber.StatusBadRequest, "limit must be between 1 and 100 and offset must not be negative")
+	}
+
+	hits, total, err := h.repo.WithContext(c.UserContext()).Search(params)
+	if err != nil {
+		return storeError(err, "Failed to search projects")
+	}
+
+	results := make([]fiber.Map, len(hits))
+	for i, hit := range hits {
+		results[i] = fiber.Map{
+			"project": hit.Project,
+			"rank":    hit.Rank,
+			"highlights": fiber.Map{
+				"title":       hit.TitleHighlight,
+				"description": hit.DescriptionHighlight,
+			},
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   results,
+		"total":  total,
+		"limit":  params.Limit,
+		"offset": params.Offset,
+	})
 }
 
 // GetProjectByID godoc
 // @Summary      Get project by ID
-// @Description  Get detailed information about a specific project
+// @Description  Get detailed information about a specific project. Draft and review projects are only visible to their owners. The weak ETag header covers the project version and its funding data; send it back in If-None-Match to get 304 when nothing changed. If-Match on PATCH/PUT takes the strong version ETag ("<version>") instead
 // @Tags         Projects
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        id             path      string  true   "Project ID (numeric timestamped ID)"
+// @Param        If-None-Match  header    string  false  "ETag from a previous response"
 // @Success      200  {object}  model.ProjectSwagger
+// @Header       200  {string}  ETag  "Weak ETag of the project version and funding data"
+// @Success      304  "Not modified"
 // @Failure      400  {object}  model.ErrorResponse
 // @Failure      404  {object}  model.ErrorResponse
 // @Failure      500  {object}  model.ErrorResponse
@@ -55,22 +178,30 @@ func (h *ProjectHandler) GetProjectByID(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
-	project, err := h.repo.GetByID(id)
+	project, err := h.repo.WithContext(c.UserContext()).GetByID(id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch project",
-		})
+		return storeError(err, "Failed to fetch project")
 	}
 
 	if project == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Project not found",
-		})
+		return repository.ErrProjectNotFound
+	}
+
+	visible, err := auth.CanViewProject(h.repo.WithContext(c.UserContext()), project, auth.WalletFromCtx(c))
+	if err != nil {
+		return storeError(err, "Failed to fetch project")
+	}
+	if !visible {
+		return repository.ErrProjectNotFound
+	}
+
+	etag := projectResponseETag(project)
+	c.Set(fiber.HeaderETag, etag)
+	if match := c.Get(fiber.HeaderIfNoneMatch); match != "" && etagMatches(match, etag, true) {
+		return c.SendStatus(fiber.StatusNotModified)
 	}
 
 	return c.JSON(project)
@@ -78,201 +209,528 @@ func (h *ProjectHandler) GetProjectByID(c *fiber.Ctx) error {
 
 // CreateProject godoc
 // @Summary      Create new project
-// @Description  Create a new crowdfunding project entry. UUID v7 will be auto-generated
+// @Description  Create a new crowdfunding project entry in draft status. The creator is the authenticated wallet. goal_amount must be positive and end_date in the future when provided
 // @Tags         Projects
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        project  body      model.ProjectCreate  true  "Project data"
 // @Success      201      {object}  model.ProjectSwagger
 // @Failure      400      {object}  model.ErrorResponse
+// @Failure      401      {object}  model.ErrorResponse
+// @Failure      403      {object}  model.ErrorResponse
 // @Failure      500      {object}  model.ErrorResponse
 // @Router       /projects [post]
 func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
-	var project model.Project
-
-	if err := c.BodyParser(&project); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	project, err := bindProject(c, nil)
+	if err != nil {
+		return err
 	}
 
-	// Validasi field yang wajib diisi
-	if strings.TrimSpace(project.CreatorWalletAddress) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "creator_wallet_address is required",
-		})
+	// Creator selalu wallet yang sedang login
+	wallet := auth.WalletFromCtx(c)
+	if project.CreatorWalletAddress == "" {
+		project.CreatorWalletAddress = wallet
+	} else if !auth.SameAddress(project.CreatorWalletAddress, wallet) {
+		return apperror.Forbidden("creator_wallet_address must match the authenticated wallet").WithField("creator_wallet_address", "creator_wallet_address must match the authenticated wallet")
 	}
 
-	if strings.TrimSpace(project.Title) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "title is required",
-		})
-	}
+	// Project baru selalu dimulai sebagai draft; status berikutnya lewat endpoint lifecycle
+	now := time.Now()
+	project.Status = model.ProjectStatusDraft
+	project.StatusChangedAt = &now
 
 	// ID will be generated by BeforeCreate hook (numeric timestamped ID)
 
-	if err := h.repo.Create(&project); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to create project",
-		})
+	if err := h.repo.WithContext(c.UserContext()).Create(&project); err != nil {
+		return storeError(err, "Failed to create project")
 	}
+	metrics.ProjectsCreated.Inc()
 
+	c.Set(fiber.HeaderETag, projectETag(&project))
 	return c.Status(fiber.StatusCreated).JSON(project)
 }
 
 // UpdateProject godoc
 // @Summary      Update project
-// @Description  Partially update project information
+// @Description  Partially update project information with JSON Merge Patch (RFC 7396). Omitted fields are left unchanged, null clears a field (goal_amount and end_date only while the project is a draft), and links replaces the whole list. Unknown fields are rejected. Returns the reloaded project with its links. If-Match must carry the current ETag; a stale ETag returns 412
 // @Tags         Projects
 // @Accept       json
+// @Accept       application/merge-patch+json
 // @Produce      json
-// @Param        id       path      string             true  "Project ID (numeric timestamped ID)"
-// @Param        project  body      model.ProjectPatch  true  "Fields to update"
+// @Security     BearerAuth
+// @Param        id        path      string             true  "Project ID (numeric timestamped ID)"
+// @Param        If-Match  header    string             true  "Current project version as a strong ETag (the version field in double quotes)"
+// @Param        project   body      model.ProjectPatch  true  "Fields to update"
 // @Success      200      {object}  model.ProjectSwagger
+// @Header       200      {string}  ETag  "New project version"
 // @Failure      400      {object}  model.ErrorResponse
+// @Failure      401      {object}  model.ErrorResponse
+// @Failure      403      {object}  model.ErrorResponse
 // @Failure      404      {object}  model.ErrorResponse
+// @Failure      412      {object}  model.ErrorResponse
+// @Failure      415      {object}  model.ErrorResponse
+// @Failure      428      {object}  model.ErrorResponse
 // @Failure      500      {object}  model.ErrorResponse
 // @Router       /projects/{id} [patch]
 func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	var patch model.ProjectPatch
+	sent, err := bindMergePatch(c, &patch)
+	if err != nil {
+		return err
+	}
+	if len(sent) == 0 {
+		return problem.New(fiber.StatusBadRequest, "Request body has no fields to update")
 	}
 
-	var updates map[string]interface{}
-	if err := c.BodyParser(&updates); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	current, err := h.repo.WithContext(c.UserContext()).GetByID(id)
+	if err != nil {
+		return storeError(err, "Failed to update project")
+	}
+	if current == nil {
+		return repository.ErrProjectNotFound
+	}
+	if err := checkIfMatch(c, current); err != nil {
+		return err
 	}
 
-	// Hapus field yang tidak boleh diupdate
-	delete(updates, "id")
-	delete(updates, "created_at")
+	changes, err := projectPatchChanges(patch, sent, current)
+	if err != nil {
+		return err
+	}
 
-	project, err := h.repo.UpdatePartial(id, updates)
+	project, err := h.repo.WithContext(c.UserContext()).UpdatePartial(id, current.Version, changes)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update project",
-		})
+		return storeError(err, "Failed to update project")
 	}
 
 	if project == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Project not found",
-		})
+		return repository.ErrProjectNotFound
 	}
 
+	c.Set(fiber.HeaderETag, projectETag(project))
 	return c.JSON(project)
 }
 
 // ReplaceProject godoc
 // @Summary      Replace project
-// @Description  Fully replace project data including links (PUT). Links provided will replace existing links.
+// @Description  Fully replace an existing project including links (PUT). Links provided will replace existing links. The body is validated like POST /projects, with the same status-dependent funding rules as PATCH: outside draft goal_amount and end_date cannot be cleared, and an unchanged end_date may be in the past. Unknown IDs return 404 (create projects with POST). creator_wallet_address, created_at, status and investors are owned by the server and kept; creator_wallet_address may be omitted or repeated but not changed. If-Match must carry the current ETag; a stale ETag returns 412
 // @Tags         Projects
 // @Accept       json
 // @Produce      json
-// @Param        id       path      string            true  "Project ID (numeric timestamped ID)"
-// @Param        project  body      model.ProjectCreate  true  "Full project data (links included)"
+// @Security     BearerAuth
+// @Param        id        path      string               true  "Project ID (numeric timestamped ID)"
+// @Param        If-Match  header    string               true  "Current project version as a strong ETag (the version field in double quotes)"
+// @Param        project   body      model.ProjectCreate  true  "Full project data (links included)"
 // @Success      200      {object}  model.ProjectSwagger
+// @Header       200      {string}  ETag  "New project version"
 // @Failure      400      {object}  model.ErrorResponse
+// @Failure      401      {object}  model.ErrorResponse
+// @Failure      403      {object}  model.ErrorResponse
 // @Failure      404      {object}  model.ErrorResponse
+// @Failure      412      {object}  model.ErrorResponse
+// @Failure      428      {object}  model.ErrorResponse
 // @Failure      500      {object}  model.ErrorResponse
 // @Router       /projects/{id} [put]
 func (h *ProjectHandler) ReplaceProject(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
-	var project model.Project
-	if err := c.BodyParser(&project); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	// PUT hanya mengganti project yang sudah ada; project baru dibuat lewat POST /projects
+	current, err := h.repo.WithContext(c.UserContext()).GetByID(id)
+	if err != nil {
+		return storeError(err, "Failed to update project")
+	}
+	if current == nil {
+		return repository.ErrProjectNotFound
+	}
+	if err := checkIfMatch(c, current); err != nil {
+		return err
+	}
+
+	project, err := bindProject(c, current)
+	if err != nil {
+		return err
 	}
 
-	// ensure ID matches path
+	// Creator dan created_at dikelola server sehingga tidak ikut terganti
+	if project.CreatorWalletAddress != "" && !auth.SameAddress(project.CreatorWalletAddress, current.CreatorWalletAddress) {
+		return apperror.Forbidden("creator_wallet_address cannot be changed").WithField("creator_wallet_address", "creator_wallet_address cannot be changed")
+	}
+	project.CreatorWalletAddress = current.CreatorWalletAddress
+	project.CreatedAt = current.CreatedAt
+
+	// ensure ID matches path; versi dipakai repository untuk menolak penulisan yang bersamaan
 	project.ID = id
+	project.Version = current.Version
 
-	if err := h.repo.Update(&project); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update project",
-		})
+	if err := h.repo.WithContext(c.UserContext()).Update(&project); err != nil {
+		return storeError(err, "Failed to update project")
 	}
 
+	c.Set(fiber.HeaderETag, projectETag(&project))
 	return c.JSON(project)
 }
 
-// AddInvestor godoc
-// @Summary      Add investor to project
-// @Description  Add an investor's wallet address to a project
+// DeleteProject godoc
+// @Summary      Delete project
+// @Description  Soft-delete a project. It disappears from every endpoint but can be restored until it is purged after the retention window
 // @Tags         Projects
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
-// @Param        body body      model.AddInvestorRequest  true  "Investor wallet address"
 // @Success      200  {object}  model.GenericMessage
 // @Failure      400  {object}  model.ErrorResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id} [delete]
+func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	if err := h.repo.WithContext(c.UserContext()).Delete(id); err != nil {
+		return storeError(err, "Failed to delete project")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project deleted successfully",
+	})
+}
+
+// RestoreProject godoc
+// @Summary      Restore deleted project
+// @Description  Undo a soft delete. Only the creator, co-owners and admins may restore a project
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Success      200  {object}  model.ProjectSwagger
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
 // @Failure      404  {object}  model.ErrorResponse
 // @Failure      409  {object}  model.ErrorResponse
 // @Failure      500  {object}  model.ErrorResponse
-// @Router       /projects/{id}/investors [post]
-func (h *ProjectHandler) AddInvestor(c *fiber.Ctx) error {
+// @Router       /projects/{id}/restore [post]
+func (h *ProjectHandler) RestoreProject(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
-	var body struct {
-		WalletAddress string `json:"wallet_address"`
+	project, err := h.repo.WithContext(c.UserContext()).Restore(id)
+	if err != nil {
+		return storeError(err, "Failed to restore project")
 	}
 
-	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	if project == nil {
+		return repository.ErrProjectNotFound
 	}
 
-	if strings.TrimSpace(body.WalletAddress) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "wallet_address is required",
-		})
+	return c.JSON(project)
+}
+
+// SubmitProject godoc
+// @Summary      Submit project for review
+// @Description  Move a draft project to review. Requires goal_amount and a future end_date
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Success      200  {object}  model.ProjectSwagger
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      409  {object}  model.StatusTransitionError
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/submit [post]
+func (h *ProjectHandler) SubmitProject(c *fiber.Ctx) error {
+	return h.transitionProject(c, model.ProjectStatusReview, requireCampaignReady)
+}
+
+// PublishProject godoc
+// @Summary      Publish project
+// @Description  Move a project in review to live so it appears in public listings. Only platform admins (ADMIN_WALLETS) may publish;
+// @Description  project owners get 403, so the review step is an actual approval. The end_date must still be in the future
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Success      200  {object}  model.ProjectSwagger
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      409  {object}  model.StatusTransitionError
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/publish [post]
+func (h *ProjectHandler) PublishProject(c *fiber.Ctx) error {
+	return h.transitionProject(c, model.ProjectStatusLive, requireCampaignReady)
+}
+
+// ArchiveProject godoc
+// @Summary      Archive project
+// @Description  Archive a draft, review, funded or failed project. Live projects cannot be archived until they end
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Success      200  {object}  model.ProjectSwagger
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      409  {object}  model.StatusTransitionError
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/archive [post]
+func (h *ProjectHandler) ArchiveProject(c *fiber.Ctx) error {
+	return h.transitionProject(c, model.ProjectStatusArchived, nil)
+}
+
+// transitionProject memindahkan status project dari parameter ":id" ke status to.
+// ready (opsional) memeriksa kelengkapan project dan mengembalikan pesan error jika belum siap.
+func (h *ProjectHandler) transitionProject(c *fiber.Ctx, to string, ready func(*model.Project) string) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
-	// Validate wallet address format (basic check for Ethereum address)
-	if len(body.WalletAddress) != 42 || !strings.HasPrefix(body.WalletAddress, "0x") {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid wallet address format",
-		})
+	if ready != nil {
+		project, err := h.repo.WithContext(c.UserContext()).GetByID(id)
+		if err != nil {
+			return storeError(err, "Failed to fetch project")
+		}
+		if project == nil {
+			return repository.ErrProjectNotFound
+		}
+		// Transisi ilegal dilaporkan lebih dulu daripada kelengkapan project
+		if model.CanTransitionProjectStatus(project.Status, to) {
+			if msg := ready(project); msg != "" {
+				return problem.New(fiber.StatusBadRequest, msg)
+			}
+		}
 	}
 
-	err = h.repo.AddInvestor(id, body.WalletAddress)
+	project, err := h.repo.WithContext(c.UserContext()).TransitionStatus(id, to)
 	if err != nil {
-		if err.Error() == "project not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Project not found",
-			})
+		return storeError(err, "Failed to update project status")
+	}
+
+	if project == nil {
+		return repository.ErrProjectNotFound
+	}
+
+	return c.JSON(project)
+}
+
+// requireCampaignReady memastikan project punya target dan deadline yang masih berjalan
+// sebelum masuk review atau dipublikasikan
+func requireCampaignReady(project *model.Project) string {
+	if project.GoalAmount == nil {
+		return "goal_amount is required before a project can be submitted or published"
+	}
+	if project.EndDate == nil {
+		return "end_date is required before a project can be submitted or published"
+	}
+	if !project.EndDate.After(time.Now()) {
+		return "end_date must be in the future"
+	}
+	return ""
+}
+
+// bindProject mem-parse dan memvalidasi body project lengkap. Dipakai POST (current nil) dan
+// PUT (current adalah project yang diganti) agar keduanya menerapkan aturan yang sama persis.
+func bindProject(c *fiber.Ctx, current *model.Project) (model.Project, error) {
+	var body model.ProjectCreate
+	if err := bind(c, &body); err != nil {
+		return model.Project{}, err
+	}
+	project := body.Project()
+	return project, validateProjectFunding(&project, current)
+}
+
+// validateProjectFunding memvalidasi target dan periode pendanaan project serta mengisi
+// mata uang default. Format field sudah diperiksa oleh bind; di sini hanya aturan yang
+// bergantung pada nilai (angka positif, tanggal di masa depan, urutan tanggal).
+//
+// current adalah project yang tersimpan saat PUT/PATCH (nil saat create). Aturan yang bergantung
+// pada statusnya berlaku sama untuk PUT dan PATCH: di luar draft goal_amount dan end_date tidak
+// bisa dikosongkan, dan end_date yang tidak berubah tidak harus di masa depan sehingga project
+// yang sudah berakhir tetap bisa diedit.
+func validateProjectFunding(project, current *model.Project) error {
+	var fields []apperror.FieldError
+	invalid := func(field, message string) {
+		fields = append(fields, apperror.FieldError{Field: field, Message: message})
+	}
+
+	if current != nil && current.Status != model.ProjectStatusDraft {
+		if project.GoalAmount == nil && current.GoalAmount != nil {
+			invalid("goal_amount", "goal_amount can only be cleared while the project is a draft")
 		}
-		if err.Error() == "investor already exists" {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"error": "Investor already exists in this project",
-			})
+		if project.EndDate == nil && current.EndDate != nil {
+			invalid("end_date", "end_date can only be cleared while the project is a draft")
+		}
+	}
+
+	if project.GoalAmount != nil {
+		goal, ok := new(big.Int).SetString(strings.TrimSpace(*project.GoalAmount), 10)
+		if !ok || goal.Sign() <= 0 {
+			invalid("goal_amount", "goal_amount must be a positive integer amount in the token's smallest unit")
+		} else {
+			normalized := goal.String()
+			project.GoalAmount = &normalized
+		}
+	}
+
+	endDateUnchanged := current != nil && current.EndDate != nil && project.EndDate != nil && project.EndDate.Equal(*current.EndDate)
+	if project.EndDate != nil && !endDateUnchanged && !project.EndDate.After(time.Now()) {
+		invalid("end_date", "end_date must be in the future")
+	}
+
+	if project.StartDate != nil && project.EndDate != nil && !project.StartDate.Before(*project.EndDate) {
+		invalid("start_date", "start_date must be before end_date")
+	}
+
+	project.Currency = strings.ToUpper(strings.TrimSpace(project.Currency))
+	if project.Currency == "" {
+		project.Currency = "ETH"
+	}
+	if project.CurrencyChainID == 0 {
+		project.CurrencyChainID = 1
+	}
+
+	return validationError(fields)
+}
+
+// projectPatchChanges mengubah merge patch yang sudah divalidasi menjadi perubahan untuk UpdatePartial.
+// Key bernilai null mengosongkan field: kolom teks menjadi "", goal_amount dan end_date menjadi NULL
+// dan links dihapus semua. title tidak boleh dikosongkan. goal_amount dan end_date divalidasi
+// dengan validateProjectFunding seperti PUT, atas hasil penerapan patch ke nilai yang tersimpan.
+func projectPatchChanges(patch model.ProjectPatch, sent map[string]bool, current *model.Project) (repository.ProjectChanges, error) {
+	changes := repository.ProjectChanges{Columns: map[string]interface{}{}}
+	var fields []apperror.FieldError
+
+	texts := []struct {
+		column string
+		value  *string
+	}{
+		{"title", patch.Title},
+		{"description", patch.Description},
+		{"cover_image_url", patch.CoverImageURL},
+		{"developer_name", patch.DeveloperName},
+		{"genre", patch.Genre},
+		{"game_type", patch.GameType},
+	}
+	for _, text := range texts {
+		isNull, ok := sent[text.column]
+		switch {
+		case !ok:
+		case !isNull:
+			changes.Columns[text.column] = *text.value
+		case text.column == "title":
+			fields = append(fields, apperror.FieldError{Field: "title", Message: "title cannot be null"})
+		default:
+			changes.Columns[text.column] = ""
+		}
+	}
+
+	// Field pendanaan yang tidak dikirim tetap bernilai seperti yang tersimpan
+	funding := model.Project{StartDate: current.StartDate, GoalAmount: current.GoalAmount, EndDate: current.EndDate}
+	if isNull, ok := sent["goal_amount"]; ok {
+		funding.GoalAmount = nil
+		if !isNull {
+			funding.GoalAmount = patch.GoalAmount
+		}
+	}
+	if isNull, ok := sent["end_date"]; ok {
+		funding.EndDate = nil
+		if !isNull {
+			// Format sudah diperiksa oleh aturan rfc3339
+			endDate, _ := time.Parse(time.RFC3339, *patch.EndDate)
+			funding.EndDate = &endDate
+		}
+	}
+	if err := validateProjectFunding(&funding, current); err != nil {
+		fields = append(fields, apperror.As(err).Fields...)
+	}
+	if _, ok := sent["goal_amount"]; ok {
+		changes.Columns["goal_amount"] = nil
+		if funding.GoalAmount != nil {
+			changes.Columns["goal_amount"] = *funding.GoalAmount
+		}
+	}
+	if _, ok := sent["end_date"]; ok {
+		changes.Columns["end_date"] = nil
+		if funding.EndDate != nil {
+			changes.Columns["end_date"] = *funding.EndDate
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to add investor",
-		})
 	}
 
+	if _, ok := sent["links"]; ok {
+		changes.ReplaceLinks = true
+		changes.Links = patch.ExternalLinks(current.ID)
+	}
+
+	return changes, validationError(fields)
+}
+
+// AddInvestor godoc
+// @Summary      Add investor to project
+// @Description  Add an investor's wallet address to a project
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        body body      model.AddInvestorRequest  true  "Investor wallet address"
+// @Success      200  {object}  model.GenericMessage
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      409  {object}  model.ErrorResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/investors [post]
+func (h *ProjectHandler) AddInvestor(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	var body model.AddInvestorRequest
+	if err := bind(c, &body); err != nil {
+		return err
+	}
+
+	err = h.repo.WithContext(c.UserContext()).AddInvestor(id, body.WalletAddress)
+	if err != nil {
+		return storeError(err, "Failed to add investor")
+	}
+	metrics.InvestmentsRecorded.WithLabelValues(model.InvestmentSourceManual).Inc()
+
 	return c.JSON(fiber.Map{
 		"message": "Investor added successfully",
 	})
@@ -280,43 +738,36 @@ func (h *ProjectHandler) AddInvestor(c *fiber.Ctx) error {
 
 // RemoveInvestor godoc
 // @Summary      Remove investor from project
-// @Description  Remove an investor's wallet address from a project
+// @Description  Remove an investor's manually recorded ledger entries from a project. Investors with on-chain investments (ingested by the indexer) cannot be removed and return 409
 // @Tags         Projects
 // @Accept       json
 // @Produce      json
-// @Param        id             path      string  true  "Project ID (UUID v7)"
+// @Security     BearerAuth
+// @Param        id             path      string  true  "Project ID (numeric timestamped ID)"
 // @Param        walletAddress  path      string  true  "Investor wallet address"
 // @Success      200            {object}  model.GenericMessage
 // @Failure      400            {object}  model.ErrorResponse
+// @Failure      401            {object}  model.ErrorResponse
+// @Failure      403            {object}  model.ErrorResponse
 // @Failure      404            {object}  model.ErrorResponse
+// @Failure      409            {object}  model.ErrorResponse
 // @Failure      500            {object}  model.ErrorResponse
 // @Router       /projects/{id}/investors/{walletAddress} [delete]
 func (h *ProjectHandler) RemoveInvestor(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
 	walletAddress := c.Params("walletAddress")
 	if strings.TrimSpace(walletAddress) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "wallet_address is required",
-		})
+		return problem.New(fiber.StatusBadRequest, "wallet_address is required")
 	}
 
-	err = h.repo.RemoveInvestor(id, walletAddress)
+	err = h.repo.WithContext(c.UserContext()).RemoveInvestor(id, walletAddress)
 	if err != nil {
-		if err.Error() == "project not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Project not found",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to remove investor",
-		})
+		return storeError(err, "Failed to remove investor")
 	}
 
 	return c.JSON(fiber.Map{
@@ -330,7 +781,7 @@ func (h *ProjectHandler) RemoveInvestor(c *fiber.Ctx) error {
 // @Tags         Projects
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Project ID (UUID v7)"
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
 // @Success      200  {object}  model.InvestorsResponse
 // @Failure      400  {object}  model.ErrorResponse
 // @Failure      404  {object}  model.ErrorResponse
@@ -340,21 +791,12 @@ func (h *ProjectHandler) GetInvestors(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid project ID format",
-		})
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
 	}
 
-	investors, err := h.repo.GetInvestors(id)
+	investors, err := h.repo.WithContext(c.UserContext()).GetInvestors(id)
 	if err != nil {
-		if err.Error() == "project not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Project not found",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch investors",
-		})
+		return storeError(err, "Failed to fetch investors")
 	}
 
 	// Return empty array if no investors
@@ -366,3 +808,126 @@ func (h *ProjectHandler) GetInvestors(c *fiber.Ctx) error {
 		"investors": investors,
 	})
 }
+
+// GetCoOwners godoc
+// @Summary      Get project owners
+// @Description  Get the creator and all co-owners allowed to modify a project
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Success      200  {object}  model.OwnersResponse
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/owners [get]
+func (h *ProjectHandler) GetCoOwners(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	project, err := h.repo.WithContext(c.UserContext()).GetByID(id)
+	if err != nil {
+		return storeError(err, "Failed to fetch project")
+	}
+
+	if project == nil {
+		return repository.ErrProjectNotFound
+	}
+
+	coOwners, err := h.repo.WithContext(c.UserContext()).GetCoOwners(id)
+	if err != nil {
+		return storeError(err, "Failed to fetch co-owners")
+	}
+
+	return c.JSON(fiber.Map{
+		"creator_wallet_address": project.CreatorWalletAddress,
+		"co_owners":              coOwners,
+	})
+}
+
+// AddCoOwner godoc
+// @Summary      Grant co-owner
+// @Description  Allow another wallet to modify the project. Only the project creator may grant co-owners
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        body body      model.CoOwnerRequest  true  "Co-owner wallet address"
+// @Success      201  {object}  model.ProjectCoOwner
+// @Failure      400  {object}  model.ErrorResponse
+// @Failure      401  {object}  model.ErrorResponse
+// @Failure      403  {object}  model.ErrorResponse
+// @Failure      404  {object}  model.ErrorResponse
+// @Failure      409  {object}  model.ErrorResponse
+// @Failure      500  {object}  model.ErrorResponse
+// @Router       /projects/{id}/owners [post]
+func (h *ProjectHandler) AddCoOwner(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	var body model.CoOwnerRequest
+	if err := bind(c, &body); err != nil {
+		return err
+	}
+
+	wallet := auth.WalletFromCtx(c)
+	if auth.SameAddress(body.WalletAddress, wallet) {
+		return problem.New(fiber.StatusBadRequest, "The creator is already an owner of this project")
+	}
+
+	coOwner := model.ProjectCoOwner{
+		ProjectID:     id,
+		WalletAddress: body.WalletAddress,
+		GrantedBy:     wallet,
+	}
+
+	if err := h.repo.WithContext(c.UserContext()).AddCoOwner(&coOwner); err != nil {
+		return storeError(err, "Failed to add co-owner")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(coOwner)
+}
+
+// RemoveCoOwner godoc
+// @Summary      Revoke co-owner
+// @Description  Revoke a co-owner's access to the project. Only the project creator may revoke co-owners
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id             path      string  true  "Project ID (numeric timestamped ID)"
+// @Param        walletAddress  path      string  true  "Co-owner wallet address"
+// @Success      200            {object}  model.GenericMessage
+// @Failure      400            {object}  model.ErrorResponse
+// @Failure      401            {object}  model.ErrorResponse
+// @Failure      403            {object}  model.ErrorResponse
+// @Failure      404            {object}  model.ErrorResponse
+// @Failure      500            {object}  model.ErrorResponse
+// @Router       /projects/{id}/owners/{walletAddress} [delete]
+func (h *ProjectHandler) RemoveCoOwner(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return problem.New(fiber.StatusBadRequest, "Invalid project ID format")
+	}
+
+	walletAddress := c.Params("walletAddress")
+	if strings.TrimSpace(walletAddress) == "" {
+		return problem.New(fiber.StatusBadRequest, "wallet_address is required")
+	}
+
+	if err := h.repo.WithContext(c.UserContext()).RemoveCoOwner(id, walletAddress); err != nil {
+		return storeError(err, "Failed to remove co-owner")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Co-owner removed successfully",
+	})
+}
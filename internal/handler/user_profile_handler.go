@@ -4,17 +4,20 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/auth"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
 )
 
 // UserProfileHandler menangani HTTP requests untuk user profiles
 type UserProfileHandler struct {
-	repo *repository.UserProfileRepository
+	repo repository.UserProfileStore
 }
 
 // NewUserProfileHandler membuat instance baru dari UserProfileHandler
-func NewUserProfileHandler(repo *repository.UserProfileRepository) *UserProfileHandler {
+func NewUserProfileHandler(repo repository.UserProfileStore) *UserProfileHandler {
 	return &UserProfileHandler{repo: repo}
 }
 
@@ -34,22 +37,16 @@ func (h *UserProfileHandler) GetProfileByWalletAddress(c *fiber.Ctx) error {
 	walletAddress := c.Params("walletAddress")
 
 	if strings.TrimSpace(walletAddress) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Wallet address is required",
-		})
+		return problem.New(fiber.StatusBadRequest, "Wallet address is required")
 	}
 
-	profile, err := h.repo.GetByWalletAddress(walletAddress)
+	profile, err := h.repo.WithContext(c.UserContext()).GetByWalletAddress(walletAddress)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch profile",
-		})
+		return storeError(err, "Failed to fetch profile")
 	}
 
 	if profile == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Profile not found",
-		})
+		return apperror.NotFound("Profile not found")
 	}
 
 	return c.JSON(profile)
@@ -57,14 +54,18 @@ func (h *UserProfileHandler) GetProfileByWalletAddress(c *fiber.Ctx) error {
 
 // UpsertProfile godoc
 // @Summary      Create or update user profile
-// @Description  Create a new profile or update existing one (Upsert operation)
+// @Description  Create a new profile or update existing one (Upsert operation). Only the owner of the wallet may do this.
+// @Description  kyc_status is managed by the server: new profiles start as "unverified" and updates keep the stored value
 // @Tags         User Profiles
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        walletAddress  path      string             true  "Ethereum Wallet Address (42 chars)"
-// @Param        profile        body      model.UserProfile  true  "User profile data"
+// @Param        profile        body      model.UserProfileUpsert  true  "User profile data"
 // @Success      200            {object}  model.UserProfile
 // @Failure      400            {object}  model.ErrorResponse
+// @Failure      401            {object}  model.ErrorResponse
+// @Failure      403            {object}  model.ErrorResponse
 // @Failure      409            {object}  model.ErrorResponse
 // @Failure      500            {object}  model.ErrorResponse
 // @Router       /profiles/{walletAddress} [put]
@@ -72,50 +73,25 @@ func (h *UserProfileHandler) UpsertProfile(c *fiber.Ctx) error {
 	walletAddress := c.Params("walletAddress")
 
 	if strings.TrimSpace(walletAddress) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Wallet address is required",
-		})
+		return problem.New(fiber.StatusBadRequest, "Wallet address is required")
 	}
 
-	var profile model.UserProfile
-	if err := c.BodyParser(&profile); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	// Hanya pemilik wallet yang boleh mengubah profilnya sendiri
+	if !auth.SameAddress(walletAddress, auth.WalletFromCtx(c)) {
+		return apperror.Forbidden("You can only update your own profile")
 	}
 
-	// Set wallet address dari URL param
-	profile.WalletAddress = walletAddress
-
-	// Validasi field yang wajib diisi
-	if strings.TrimSpace(profile.Username) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "username is required",
-		})
-	}
-
-	if strings.TrimSpace(profile.Email) == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "email is required",
-		})
+	var body model.UserProfileUpsert
+	if err := bind(c, &body); err != nil {
+		return err
 	}
 
-	// Set default KYC status jika kosong
-	if strings.TrimSpace(profile.KYCStatus) == "" {
-		profile.KYCStatus = "unverified"
-	}
+	// Wallet address diambil dari URL param
+	profile := body.UserProfile(walletAddress)
 
-	if err := h.repo.Upsert(&profile); err != nil {
-		// Check jika error karena duplicate username atau email
-		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"error": "Username or email already exists",
-			})
-		}
-
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to create or update profile",
-		})
+	// Unique violation pada username atau email dikembalikan repository sebagai 409
+	if err := h.repo.WithContext(c.UserContext()).Upsert(&profile); err != nil {
+		return storeError(err, "Failed to create or update profile")
 	}
 
 	return c.JSON(profile)
@@ -0,0 +1,129 @@
+package indexer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"golang.org/x/crypto/sha3"
+)
+
+// Signature event kontrak crowdfunding. projectId & investor di-index (topic 1 & 2),
+// token & amount berada di data log (masing-masing satu word 32 byte).
+const (
+	InvestedEventSignature = "Invested(uint256,address,address,uint256)"
+	RefundedEventSignature = "Refunded(uint256,address,address,uint256)"
+)
+
+var (
+	investedTopic = eventTopic(InvestedEventSignature)
+	refundedTopic = eventTopic(RefundedEventSignature)
+)
+
+// eventTopic menghitung topic0 (keccak256 dari signature event)
+func eventTopic(signature string) string {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(signature))
+	return "0x" + hex.EncodeToString(h.Sum(nil))
+}
+
+// decodedEvent adalah event Invested/Refunded yang sudah didekode
+type decodedEvent struct {
+	Kind         string
+	ProjectID    uint64
+	Investor     string
+	TokenAddress string
+	Amount       string
+	BlockNumber  uint64
+	TxHash       string
+	LogIndex     uint
+}
+
+// decodeLog mendekode log Invested/Refunded. Log dengan topic lain mengembalikan ok=false.
+func decodeLog(l Log) (ev decodedEvent, ok bool, err error) {
+	if len(l.Topics) == 0 {
+		return ev, false, nil
+	}
+	switch strings.ToLower(l.Topics[0]) {
+	case investedTopic:
+		ev.Kind = model.InvestmentKindInvestment
+	case refundedTopic:
+		ev.Kind = model.InvestmentKindRefund
+	default:
+		return ev, false, nil
+	}
+
+	if len(l.Topics) != 3 {
+		return ev, false, fmt.Errorf("expected 3 topics, got %d", len(l.Topics))
+	}
+
+	projectID, err := wordToBig(l.Topics[1])
+	if err != nil {
+		return ev, false, err
+	}
+	if !projectID.IsUint64() {
+		return ev, false, fmt.Errorf("project id %s does not fit in uint64", projectID)
+	}
+	ev.ProjectID = projectID.Uint64()
+
+	if ev.Investor, err = wordToAddress(l.Topics[2]); err != nil {
+		return ev, false, err
+	}
+
+	data := strings.TrimPrefix(l.Data, "0x")
+	if len(data) != 128 {
+		return ev, false, fmt.Errorf("expected 64 bytes of data, got %d", len(data)/2)
+	}
+	if ev.TokenAddress, err = wordToAddress(data[:64]); err != nil {
+		return ev, false, err
+	}
+	if ev.TokenAddress == zeroAddress {
+		ev.TokenAddress = "" // native coin
+	}
+	amount, err := wordToBig(data[64:])
+	if err != nil {
+		return ev, false, err
+	}
+	ev.Amount = amount.String()
+
+	if ev.BlockNumber, err = parseQuantity(l.BlockNumber); err != nil {
+		return ev, false, err
+	}
+	logIndex, err := parseQuantity(l.LogIndex)
+	if err != nil {
+		return ev, false, err
+	}
+	ev.LogIndex = uint(logIndex)
+	ev.TxHash = strings.ToLower(l.TransactionHash)
+
+	return ev, true, nil
+}
+
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// wordToBig mendekode satu word ABI (32 byte hex, dengan/tanpa 0x) menjadi big.Int
+func wordToBig(word string) (*big.Int, error) {
+	word = strings.TrimPrefix(word, "0x")
+	if len(word) != 64 {
+		return nil, fmt.Errorf("invalid ABI word length %d", len(word))
+	}
+	n, ok := new(big.Int).SetString(word, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid ABI word %q", word)
+	}
+	return n, nil
+}
+
+// wordToAddress mengambil 20 byte terakhir dari satu word ABI sebagai address (huruf kecil)
+func wordToAddress(word string) (string, error) {
+	word = strings.TrimPrefix(word, "0x")
+	if len(word) != 64 {
+		return "", fmt.Errorf("invalid ABI word length %d", len(word))
+	}
+	if _, err := hex.DecodeString(word); err != nil {
+		return "", err
+	}
+	return "0x" + strings.ToLower(word[24:]), nil
+}
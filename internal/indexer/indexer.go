@@ -0,0 +1,228 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/metrics"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+)
+
+// Chain adalah operasi JSON-RPC yang dibutuhkan indexer (diimplementasikan oleh RPCClient)
+type Chain interface {
+	ChainID(ctx context.Context) (int64, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	BlockTimestamp(ctx context.Context, number uint64) (time.Time, error)
+	GetLogs(ctx context.Context, filter LogFilter) ([]Log, error)
+}
+
+// Store adalah penyimpanan checkpoint dan ledger yang dipakai indexer. Event untuk project yang
+// belum ada disimpan sebagai pending dan dipindahkan ke ledger oleh PromotePending.
+type Store interface {
+	GetCheckpoint(name string) (*model.IndexerCheckpoint, error)
+	ProjectExists(id uint64) (bool, error)
+	SaveBatch(checkpoint *model.IndexerCheckpoint, investments []model.Investment, pending []model.IndexerPendingEvent) (int64, error)
+	PromotePending(chainID int64) (int64, error)
+}
+
+// Config mengatur perilaku indexer
+type Config struct {
+	Name            string        // nama checkpoint, unik per kontrak/chain
+	ContractAddress string        // alamat kontrak crowdfunding
+	ChainID         int64         // 0 = ambil dari eth_chainId
+	StartBlock      uint64        // blok awal jika belum ada checkpoint (blok deploy kontrak)
+	Confirmations   uint64        // jumlah blok konfirmasi sebelum log dianggap final (aman dari reorg)
+	BlockRange      uint64        // jumlah blok maksimum per panggilan eth_getLogs
+	PollInterval    time.Duration // jeda antar polling
+}
+
+// Indexer mem-polling log Invested/Refunded dari kontrak dan menuliskannya ke ledger investasi
+type Indexer struct {
+	cfg   Config
+	chain Chain
+	store Store
+}
+
+// New membuat instance baru dari Indexer
+func New(cfg Config, chain Chain, store Store) *Indexer {
+	if cfg.Name == "" {
+		cfg.Name = "crowdfunding:" + strings.ToLower(cfg.ContractAddress)
+	}
+	if cfg.BlockRange == 0 {
+		cfg.BlockRange = 2000
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 15 * time.Second
+	}
+	return &Indexer{cfg: cfg, chain: chain, store: store}
+}
+
+// Run menjalankan polling sampai ctx dibatalkan. Error per siklus hanya di-log
+// agar gangguan sementara pada node RPC tidak menghentikan indexer.
+func (ix *Indexer) Run(ctx context.Context) error {
+	if ix.cfg.ChainID == 0 {
+		chainID, err := ix.chain.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("indexer: fetch chain id: %w", err)
+		}
+		ix.cfg.ChainID = chainID
+	}
+
+	slog.InfoContext(ctx, "indexer started", "indexer", ix.cfg.Name, "chain_id", ix.cfg.ChainID, "confirmations", ix.cfg.Confirmations)
+
+	ticker := time.NewTicker(ix.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := ix.SyncOnce(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "indexer sync failed", "indexer", ix.cfg.Name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("indexer stopped", "indexer", ix.cfg.Name)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncOnce memproses semua blok yang sudah cukup konfirmasi sejak checkpoint terakhir
+// dan mengembalikan jumlah entri ledger yang dicatat (entri duplikat tidak dihitung). Setiap rentang blok disimpan
+// bersama checkpoint-nya secara atomik, jadi proses yang terhenti di tengah akan
+// melanjutkan dari rentang terakhir yang berhasil. Event untuk project yang belum ada
+// disimpan sebagai pending dan dicatat ke ledger pada sync berikutnya setelah project dibuat.
+func (ix *Indexer) SyncOnce(ctx context.Context) (int, error) {
+	if ix.cfg.ChainID == 0 {
+		return 0, fmt.Errorf("indexer: chain id is not set")
+	}
+
+	promoted, err := ix.store.PromotePending(ix.cfg.ChainID)
+	if err != nil {
+		return 0, err
+	}
+	metrics.InvestmentsRecorded.WithLabelValues(model.InvestmentSourceIndexed).Add(float64(promoted))
+	recorded := int(promoted)
+
+	checkpoint, err := ix.store.GetCheckpoint(ix.cfg.Name)
+	if err != nil {
+		return recorded, err
+	}
+
+	from := ix.cfg.StartBlock
+	if checkpoint != nil {
+		if checkpoint.ChainID != ix.cfg.ChainID {
+			return recorded, fmt.Errorf("indexer: checkpoint %s belongs to chain %d, not %d", ix.cfg.Name, checkpoint.ChainID, ix.cfg.ChainID)
+		}
+		from = checkpoint.LastBlock + 1
+	}
+
+	head, err := ix.chain.BlockNumber(ctx)
+	if err != nil {
+		return recorded, err
+	}
+	if head < ix.cfg.Confirmations {
+		return recorded, nil
+	}
+	safe := head - ix.cfg.Confirmations
+
+	for from <= safe {
+		to := from + ix.cfg.BlockRange - 1
+		if to > safe {
+			to = safe
+		}
+
+		investments, pending, err := ix.collect(ctx, from, to)
+		if err != nil {
+			return recorded, err
+		}
+
+		inserted, err := ix.store.SaveBatch(&model.IndexerCheckpoint{
+			Name:      ix.cfg.Name,
+			ChainID:   ix.cfg.ChainID,
+			LastBlock: to,
+		}, investments, pending)
+		if err != nil {
+			return recorded, err
+		}
+		metrics.InvestmentsRecorded.WithLabelValues(model.InvestmentSourceIndexed).Add(float64(inserted))
+
+		recorded += int(inserted)
+		from = to + 1
+	}
+
+	return recorded, nil
+}
+
+// collect mengambil dan mendekode log pada rentang blok [from, to]. Event untuk project yang
+// sudah ada menjadi entri ledger, sisanya dikembalikan sebagai event pending.
+func (ix *Indexer) collect(ctx context.Context, from, to uint64) ([]model.Investment, []model.IndexerPendingEvent, error) {
+	logs, err := ix.chain.GetLogs(ctx, LogFilter{
+		FromBlock: from,
+		ToBlock:   to,
+		Address:   ix.cfg.ContractAddress,
+		Topics:    [][]string{{investedTopic, refundedTopic}},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var investments []model.Investment
+	var pending []model.IndexerPendingEvent
+	timestamps := map[uint64]time.Time{}
+	projects := map[uint64]bool{}
+
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+
+		ev, ok, err := decodeLog(l)
+		if err != nil {
+			slog.WarnContext(ctx, "indexer skipping malformed log", "indexer", ix.cfg.Name, "tx_hash", l.TransactionHash, "log_index", l.LogIndex, "error", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		exists, checked := projects[ev.ProjectID]
+		if !checked {
+			if exists, err = ix.store.ProjectExists(ev.ProjectID); err != nil {
+				return nil, nil, err
+			}
+			projects[ev.ProjectID] = exists
+		}
+		ts, cached := timestamps[ev.BlockNumber]
+		if !cached {
+			if ts, err = ix.chain.BlockTimestamp(ctx, ev.BlockNumber); err != nil {
+				return nil, nil, err
+			}
+			timestamps[ev.BlockNumber] = ts
+		}
+
+		event := model.IndexerPendingEvent{
+			ChainID:       ix.cfg.ChainID,
+			TxHash:        ev.TxHash,
+			LogIndex:      ev.LogIndex,
+			ProjectID:     ev.ProjectID,
+			WalletAddress: ev.Investor,
+			Kind:          ev.Kind,
+			Amount:        ev.Amount,
+			TokenAddress:  ev.TokenAddress,
+			BlockNumber:   ev.BlockNumber,
+			InvestedAt:    ts,
+		}
+		if !exists {
+			slog.WarnContext(ctx, "indexer deferring event for unknown project", "indexer", ix.cfg.Name, "project_id", ev.ProjectID, "tx_hash", ev.TxHash)
+			pending = append(pending, event)
+			continue
+		}
+		investments = append(investments, event.Investment())
+	}
+
+	return investments, pending, nil
+}
@@ -0,0 +1,387 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository/memory"
+)
+
+const (
+	testContract = "0x5fbdb2315678afecb367f032d93f642f64180aa3"
+	testChainID  = 1337
+	testProject  = 42
+	laterProject = 99 // project di blok 101 yang baru dibuat setelah event-nya terjadi
+
+	investorA = "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"
+	investorB = "0x3c44cdddb6a900fa2b585dd299e03d12fa4293bc"
+	testToken = "0x9fe46736679d2d9a65f0992f2272de9f3c7fa6e0"
+)
+
+// fakeNode adalah stand-in JSON-RPC yang memutar ulang log dari testdata/logs.json
+type fakeNode struct {
+	mu   sync.Mutex
+	head uint64
+	logs []Log
+}
+
+func newFakeNode(t *testing.T, head uint64) *fakeNode {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/logs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := &fakeNode{head: head}
+	if err := json.Unmarshal(raw, &node.logs); err != nil {
+		t.Fatal(err)
+	}
+	return node
+}
+
+func (n *fakeNode) setHead(head uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.head = head
+}
+
+// blockTime adalah timestamp deterministik untuk setiap blok
+func blockTime(number uint64) time.Time {
+	return time.Unix(1700000000+int64(number)*12, 0).UTC()
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var req struct {
+		ID     int64             `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	switch req.Method {
+	case "eth_chainId":
+		result = toQuantity(testChainID)
+	case "eth_blockNumber":
+		result = toQuantity(n.head)
+	case "eth_getBlockByNumber":
+		var quantity string
+		_ = json.Unmarshal(req.Params[0], &quantity)
+		number, _ := parseQuantity(quantity)
+		result = map[string]string{"timestamp": toQuantity(uint64(blockTime(number).Unix()))}
+	case "eth_getLogs":
+		var filter struct {
+			FromBlock string `json:"fromBlock"`
+			ToBlock   string `json:"toBlock"`
+			Address   string `json:"address"`
+		}
+		_ = json.Unmarshal(req.Params[0], &filter)
+		from, _ := parseQuantity(filter.FromBlock)
+		to, _ := parseQuantity(filter.ToBlock)
+		if to > n.head {
+			http.Error(w, "toBlock beyond head", http.StatusBadRequest)
+			return
+		}
+		logs := []Log{}
+		for _, l := range n.logs {
+			block, _ := parseQuantity(l.BlockNumber)
+			if block >= from && block <= to && l.Address == filter.Address {
+				logs = append(logs, l)
+			}
+		}
+		result = logs
+	default:
+		result = nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+type indexerFixture struct {
+	node        *fakeNode
+	indexer     *Indexer
+	checkpoints *memory.IndexerCheckpointRepository
+	investments *memory.InvestmentRepository
+	projects    *memory.ProjectRepository
+}
+
+func newIndexerFixture(t *testing.T, head uint64) *indexerFixture {
+	t.Helper()
+	node := newFakeNode(t, head)
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+
+	db := memory.NewDB()
+	projects := memory.NewProjectRepository(db)
+	if err := projects.Create(&model.Project{ID: testProject, Title: "Indexed", CreatorWalletAddress: investorA, CurrencyChainID: testChainID}); err != nil {
+		t.Fatal(err)
+	}
+	checkpoints := memory.NewIndexerCheckpointRepository(db)
+
+	ix := New(Config{
+		ContractAddress: testContract,
+		StartBlock:      90,
+		Confirmations:   12,
+		BlockRange:      10,
+	}, NewRPCClient(server.URL, server.Client()), NewRepositoryStore(checkpoints, projects))
+
+	chainID, err := ix.chain.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ix.cfg.ChainID = chainID
+
+	return &indexerFixture{
+		node:        node,
+		indexer:     ix,
+		checkpoints: checkpoints,
+		investments: memory.NewInvestmentRepository(db),
+		projects:    projects,
+	}
+}
+
+func (f *indexerFixture) ledger(t *testing.T) []model.Investment {
+	t.Helper()
+	investments, _, err := f.investments.ListByProject(testProject, repository.InvestmentListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return investments
+}
+
+func (f *indexerFixture) lastBlock(t *testing.T) uint64 {
+	t.Helper()
+	checkpoint, err := f.checkpoints.GetByName(f.indexer.cfg.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint == nil {
+		t.Fatal("checkpoint was not saved")
+	}
+	if checkpoint.ChainID != testChainID {
+		t.Fatalf("checkpoint chain id = %d, want %d", checkpoint.ChainID, testChainID)
+	}
+	return checkpoint.LastBlock
+}
+
+func findByTx(investments []model.Investment, txHash string) *model.Investment {
+	for i := range investments {
+		if investments[i].TxHash != nil && *investments[i].TxHash == txHash {
+			return &investments[i]
+		}
+	}
+	return nil
+}
+
+// txHash membuat hash transaksi fixture dari satu digit hex, mis. "a" -> 0xaaaa...
+func txHash(digit string) string {
+	return "0x" + strings.Repeat(digit, 64)
+}
+
+func TestSyncOnceDecodesFixtureLogs(t *testing.T) {
+	f := newIndexerFixture(t, 125) // blok aman = 125 - 12 = 113
+
+	recorded, err := f.indexer.SyncOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Blok 100, 105 dan 110; event project yang belum ada (blok 101) ditunda dan log removed (111) dilewati
+	if recorded != 3 {
+		t.Fatalf("recorded = %d, want 3", recorded)
+	}
+
+	ledger := f.ledger(t)
+	if len(ledger) != 3 {
+		t.Fatalf("ledger has %d entries, want 3", len(ledger))
+	}
+
+	tests := []struct {
+		tx     string
+		kind   string
+		wallet string
+		token  string
+		amount string
+		block  uint64
+		index  uint
+	}{
+		{txHash("a"), model.InvestmentKindInvestment, investorA, "", "1000000000000000000", 100, 0},
+		{txHash("c"), model.InvestmentKindInvestment, investorB, testToken, "2500000", 105, 3},
+		{txHash("d"), model.InvestmentKindRefund, investorA, "", "1000000000000000000", 110, 1},
+	}
+	for _, tt := range tests {
+		inv := findByTx(ledger, tt.tx)
+		if inv == nil {
+			t.Fatalf("no ledger entry for tx %s", tt.tx)
+		}
+		if inv.Kind != tt.kind || inv.WalletAddress != tt.wallet || inv.TokenAddress != tt.token || inv.Amount != tt.amount {
+			t.Errorf("tx %s decoded as kind=%s wallet=%s token=%q amount=%s", tt.tx, inv.Kind, inv.WalletAddress, inv.TokenAddress, inv.Amount)
+		}
+		if inv.Source != model.InvestmentSourceIndexed {
+			t.Errorf("tx %s source = %q, want %q", tt.tx, inv.Source, model.InvestmentSourceIndexed)
+		}
+		if inv.ChainID != testChainID || inv.LogIndex != tt.index || inv.BlockNumber == nil || *inv.BlockNumber != tt.block {
+			t.Errorf("tx %s has chain=%d log_index=%d block=%v", tt.tx, inv.ChainID, inv.LogIndex, inv.BlockNumber)
+		}
+		if !inv.InvestedAt.Equal(blockTime(tt.block)) {
+			t.Errorf("tx %s invested_at = %s, want %s", tt.tx, inv.InvestedAt, blockTime(tt.block))
+		}
+	}
+
+	if last := f.lastBlock(t); last != 113 {
+		t.Fatalf("checkpoint last_block = %d, want 113", last)
+	}
+}
+
+func TestSyncOnceDefersEventsForUnknownProjects(t *testing.T) {
+	f := newIndexerFixture(t, 125)
+
+	if _, err := f.indexer.SyncOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if last := f.lastBlock(t); last != 113 {
+		t.Fatalf("checkpoint last_block = %d, want 113", last)
+	}
+
+	// Checkpoint sudah melewati blok 101; event-nya tetap dicatat setelah project dibuat
+	if err := f.projects.Create(&model.Project{ID: laterProject, Title: "Created later", CreatorWalletAddress: investorB}); err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := f.indexer.SyncOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded != 1 {
+		t.Fatalf("recorded = %d, want 1", recorded)
+	}
+
+	ledger, _, err := f.investments.ListByProject(laterProject, repository.InvestmentListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv := findByTx(ledger, txHash("b"))
+	if len(ledger) != 1 || inv == nil || inv.Source != model.InvestmentSourceIndexed || inv.ChainID != testChainID ||
+		inv.BlockNumber == nil || *inv.BlockNumber != 101 || !inv.InvestedAt.Equal(blockTime(101)) {
+		t.Fatalf("ledger for project %d = %+v", laterProject, ledger)
+	}
+
+	// Event yang sudah dipindahkan tidak dicatat dua kali
+	if recorded, err := f.indexer.SyncOnce(context.Background()); err != nil || recorded != 0 {
+		t.Fatalf("second sync recorded %d, err %v", recorded, err)
+	}
+}
+
+func TestSyncOnceWaitsForConfirmations(t *testing.T) {
+	f := newIndexerFixture(t, 125)
+
+	if _, err := f.indexer.SyncOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if inv := findByTx(f.ledger(t), txHash("f")); inv != nil {
+		t.Fatal("log at block 120 was ingested before it had 12 confirmations")
+	}
+
+	// Head belum bergerak: tidak ada yang diproses dan checkpoint tetap
+	recorded, err := f.indexer.SyncOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded != 0 || f.lastBlock(t) != 113 {
+		t.Fatalf("idle sync recorded %d and moved checkpoint to %d", recorded, f.lastBlock(t))
+	}
+
+	f.node.setHead(140) // blok aman = 128
+	recorded, err = f.indexer.SyncOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded != 1 {
+		t.Fatalf("recorded = %d, want 1", recorded)
+	}
+	if inv := findByTx(f.ledger(t), txHash("f")); inv == nil || inv.Amount != "300" {
+		t.Fatal("log at block 120 was not ingested after it was confirmed")
+	}
+	if last := f.lastBlock(t); last != 128 {
+		t.Fatalf("checkpoint last_block = %d, want 128", last)
+	}
+}
+
+func TestSyncOnceReingestIsIdempotent(t *testing.T) {
+	f := newIndexerFixture(t, 140)
+
+	if _, err := f.indexer.SyncOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.ledger(t)); n != 4 {
+		t.Fatalf("ledger has %d entries, want 4", n)
+	}
+
+	// Mundurkan checkpoint (mis. setelah restore backup) sehingga semua log diproses ulang;
+	// konflik (chain_id, tx_hash, log_index) membuat entri yang sama tidak tercatat dua kali
+	rewind := &model.IndexerCheckpoint{Name: f.indexer.cfg.Name, ChainID: testChainID, LastBlock: 89}
+	if _, err := f.checkpoints.SaveBatch(rewind, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := f.indexer.SyncOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded != 0 {
+		t.Fatalf("re-ingest recorded = %d, want 0", recorded)
+	}
+
+	if n := len(f.ledger(t)); n != 4 {
+		t.Fatalf("ledger has %d entries after re-ingest, want 4", n)
+	}
+	if last := f.lastBlock(t); last != 128 {
+		t.Fatalf("checkpoint last_block = %d, want 128", last)
+	}
+}
+
+func TestFundingCountsOnlyTheProjectChain(t *testing.T) {
+	f := newIndexerFixture(t, 140)
+	if _, err := f.indexer.SyncOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	raised := func() string {
+		t.Helper()
+		project, err := f.projects.GetByID(testProject)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return project.FundingProgress.Raised
+	}
+	before := raised()
+
+	// Native coin di chain lain memakai token_address kosong yang sama, tetapi bukan mata uang project
+	block := uint64(100)
+	other := &model.Investment{
+		ProjectID: testProject, WalletAddress: investorB, Kind: model.InvestmentKindInvestment, Amount: "5000",
+		Source: model.InvestmentSourceIndexed, ChainID: 1, TxHash: stringPtr(txHash("9")), BlockNumber: &block,
+		InvestedAt: blockTime(block),
+	}
+	if err := f.investments.Create(other); err != nil {
+		t.Fatal(err)
+	}
+	if got := raised(); got != before {
+		t.Fatalf("raised = %s after an investment on another chain, want %s", got, before)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,177 @@
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RPCClient adalah klien JSON-RPC Ethereum minimal yang hanya mendukung method
+// yang dibutuhkan indexer. Endpoint bisa berupa node sungguhan maupun httptest server
+// yang memutar ulang log fixture.
+type RPCClient struct {
+	endpoint string
+	http     *http.Client
+	nextID   atomic.Int64
+}
+
+// NewRPCClient membuat instance baru dari RPCClient
+func NewRPCClient(endpoint string, httpClient *http.Client) *RPCClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &RPCClient{endpoint: endpoint, http: httpClient}
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// RPCError adalah error yang dikembalikan oleh node JSON-RPC
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
+
+// Log adalah satu entri dari eth_getLogs
+type Log struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	BlockHash        string   `json:"blockHash"`
+	LogIndex         string   `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+
+// LogFilter adalah parameter eth_getLogs
+type LogFilter struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Address   string
+	Topics    [][]string
+}
+
+func (c *RPCClient) call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      c.nextID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("json-rpc %s: unexpected HTTP status %d", method, resp.StatusCode)
+	}
+
+	var decoded rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return fmt.Errorf("json-rpc %s: %w", method, err)
+	}
+	if decoded.Error != nil {
+		return decoded.Error
+	}
+	return json.Unmarshal(decoded.Result, result)
+}
+
+// ChainID memanggil eth_chainId
+func (c *RPCClient) ChainID(ctx context.Context) (int64, error) {
+	var hex string
+	if err := c.call(ctx, "eth_chainId", &hex); err != nil {
+		return 0, err
+	}
+	n, err := parseQuantity(hex)
+	return int64(n), err
+}
+
+// BlockNumber memanggil eth_blockNumber
+func (c *RPCClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var hex string
+	if err := c.call(ctx, "eth_blockNumber", &hex); err != nil {
+		return 0, err
+	}
+	return parseQuantity(hex)
+}
+
+// BlockTimestamp mengambil timestamp sebuah blok lewat eth_getBlockByNumber
+func (c *RPCClient) BlockTimestamp(ctx context.Context, number uint64) (time.Time, error) {
+	var block struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if err := c.call(ctx, "eth_getBlockByNumber", &block, toQuantity(number), false); err != nil {
+		return time.Time{}, err
+	}
+	ts, err := parseQuantity(block.Timestamp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(ts), 0).UTC(), nil
+}
+
+// GetLogs memanggil eth_getLogs
+func (c *RPCClient) GetLogs(ctx context.Context, filter LogFilter) ([]Log, error) {
+	params := map[string]interface{}{
+		"fromBlock": toQuantity(filter.FromBlock),
+		"toBlock":   toQuantity(filter.ToBlock),
+		"address":   filter.Address,
+	}
+	if len(filter.Topics) > 0 {
+		params["topics"] = filter.Topics
+	}
+
+	var logs []Log
+	if err := c.call(ctx, "eth_getLogs", &logs, params); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// parseQuantity mengubah quantity hex JSON-RPC ("0x1a") menjadi uint64
+func parseQuantity(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return strconv.ParseUint(s[2:], 16, 64)
+}
+
+func toQuantity(n uint64) string {
+	return "0x" + strconv.FormatUint(n, 16)
+}
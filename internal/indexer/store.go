@@ -0,0 +1,35 @@
+package indexer
+
+import (
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// repositoryStore mengimplementasikan Store di atas repository Postgres
+type repositoryStore struct {
+	checkpoints repository.IndexerCheckpointStore
+	projects    repository.ProjectStore
+}
+
+// NewRepositoryStore membuat Store yang memakai repository Postgres
+func NewRepositoryStore(checkpoints repository.IndexerCheckpointStore, projects repository.ProjectStore) Store {
+	return &repositoryStore{checkpoints: checkpoints, projects: projects}
+}
+
+func (s *repositoryStore) GetCheckpoint(name string) (*model.IndexerCheckpoint, error) {
+	return s.checkpoints.GetByName(name)
+}
+
+// ProjectExists ikut menghitung project yang di-soft delete agar dana on-chain
+// tetap tercatat di ledger jika project tersebut di-restore
+func (s *repositoryStore) ProjectExists(id uint64) (bool, error) {
+	return s.projects.ExistsWithDeleted(id)
+}
+
+func (s *repositoryStore) SaveBatch(checkpoint *model.IndexerCheckpoint, investments []model.Investment, pending []model.IndexerPendingEvent) (int64, error) {
+	return s.checkpoints.SaveBatch(checkpoint, investments, pending)
+}
+
+func (s *repositoryStore) PromotePending(chainID int64) (int64, error) {
+	return s.checkpoints.PromotePending(chainID)
+}
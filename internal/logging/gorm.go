@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger menulis log GORM ke slog dengan context query, sehingga request_id ikut tercatat
+// selama repository memakai db.WithContext. Nilai parameter tidak pernah ditulis: SQL dicatat
+// dengan placeholder ($1, $2, ...). Query hanya dicatat jika gagal atau lebih lambat dari
+// SlowThreshold; pada level slog debug semua query dicatat.
+type GormLogger struct {
+	SlowThreshold time.Duration // 0 = nonaktifkan log query lambat
+	level         gormlogger.LogLevel
+}
+
+// NewGormLogger membuat GormLogger dengan level Warn (error dan query lambat)
+func NewGormLogger(slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{SlowThreshold: slowThreshold, level: gormlogger.Warn}
+}
+
+// LogMode mengembalikan salinan logger dengan level GORM yang lain
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm")
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm")
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm")
+	}
+}
+
+// Trace dipanggil GORM setelah setiap query
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	var level slog.Level
+	var msg string
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		level, msg = slog.LevelError, "query failed"
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold && l.level >= gormlogger.Warn:
+		level, msg = slog.LevelWarn, "slow query"
+	case slog.Default().Enabled(ctx, slog.LevelDebug):
+		level, msg = slog.LevelDebug, "query"
+	default:
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("component", "gorm"),
+		slog.String("sql", redactedPlaceholder.ReplaceAllString(sql, "$$$1")),
+		slog.Int64("rows", rows),
+		slog.Float64("duration_ms", durationMillis(elapsed)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	slog.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// ParamsFilter membuang nilai parameter sebelum GORM menyusun SQL untuk log
+// (implementasi gorm.ParamsFilter)
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// redactedPlaceholder mengembalikan placeholder "$1$" (hasil GORM saat parameter dibuang) menjadi "$1"
+var redactedPlaceholder = regexp.MustCompile(`\$(\d+)\$`)
@@ -0,0 +1,75 @@
+// Package logging menyiapkan logger log/slog aplikasi dan membawa request ID lewat context,
+// sehingga access log, log handler dan log query GORM dari satu request bisa dikorelasikan.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID menyimpan request ID di context
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID mengambil request ID dari context, atau "" jika tidak ada
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Setup membuat logger dengan level (debug, info, warn, error) dan format (json atau text),
+// menjadikannya logger default slog (termasuk output package log), lalu mengembalikannya.
+// Setiap record yang ditulis dengan context berisi request ID mendapat atribut request_id.
+func Setup(level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	var h slog.Handler
+	switch strings.ToLower(format) {
+	case "json":
+		h = slog.NewJSONHandler(os.Stdout, opts)
+	case "text":
+		h = slog.NewTextHandler(os.Stdout, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q (want json or text)", format)
+	}
+
+	logger := slog.New(contextHandler{h})
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+// contextHandler menambahkan atribut dari context (saat ini request_id) ke setiap record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Middleware menaruh request ID (header X-Request-ID dari middleware requestid) ke context
+// request dan menulis satu access log per request. Harus dipasang setelah requestid.
+// Handler meneruskan context lewat c.UserContext() ke slog maupun repository.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		ctx := WithRequestID(c.UserContext(), c.GetRespHeader(fiber.HeaderXRequestID))
+		c.SetUserContext(ctx)
+
+		if err := c.Next(); err != nil {
+			// Jalankan ErrorHandler sekarang agar status yang dicatat sama dengan yang dikirim.
+			// Ini satu-satunya tempat error di-resolve; middleware di luar (metrics) hanya
+			// membaca status response.
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(ctx, level, "request",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.String("route", c.Route().Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", durationMillis(time.Since(start))),
+			slog.Int("bytes", len(c.Response().Body())),
+			slog.String("ip", c.IP()),
+		)
+		return nil
+	}
+}
+
+// durationMillis mengubah durasi ke milidetik pecahan agar mudah diagregasi
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
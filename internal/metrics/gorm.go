@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+var dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "db_query_duration_seconds",
+	Help:      "GORM query latency by operation and table.",
+	Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"operation", "table"})
+
+// startKey menyimpan waktu mulai query di instance statement GORM
+const startKey = "metrics:start"
+
+// GormPlugin mencatat durasi setiap query GORM ke crowdfunding_db_query_duration_seconds.
+// Pasang dengan db.Use(metrics.GormPlugin{}).
+type GormPlugin struct{}
+
+// Name mengembalikan nama plugin (implementasi gorm.Plugin)
+func (GormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize memasang callback sebelum dan sesudah setiap jenis operasi GORM
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("metrics:before_create", before); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register("metrics:after_create", after("create")); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("metrics:before_query", before); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register("metrics:after_query", after("select")); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("metrics:before_update", before); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("metrics:after_update", after("update")); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("metrics:before_delete", before); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("metrics:before_row", before); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register("metrics:after_row", after("row")); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("gorm:raw").Register("metrics:before_raw", before); err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw"))
+}
+
+func before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+// after mengembalikan callback yang mencatat durasi query sejak callback before.
+// Query tanpa tabel (Raw/Exec) dicatat dengan table "raw".
+func after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "raw"
+		}
+		dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+	}
+}
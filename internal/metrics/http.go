@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// unmatchedRoute adalah label route untuk request yang tidak cocok dengan route mana pun,
+// agar path acak (scanner, typo) tidak menambah kardinalitas metrik
+const unmatchedRoute = "unmatched"
+
+// Middleware mencatat jumlah dan latency request per route. Label route memakai pola route
+// Fiber (mis. /api/v1/projects/:id), bukan path aslinya. Middleware ini tidak menjalankan
+// ErrorHandler sendiri: ia dipasang di luar logging.Middleware, yang sudah mengubah error
+// menjadi response, sehingga status yang dibaca di sini adalah status yang dikirim ke client.
+func Middleware() fiber.Handler {
+	var once sync.Once
+	var handlerRoutes map[string]bool
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// Route handler baru lengkap setelah app mulai melayani request, jadi dikumpulkan saat
+		// request pertama. Jika tidak ada route handler yang cocok, c.Route() berisi middleware
+		// terakhir yang berjalan (mis. "/api/v1").
+		once.Do(func() {
+			handlerRoutes = map[string]bool{}
+			for _, route := range c.App().GetRoutes(true) {
+				handlerRoutes[route.Method+" "+route.Path] = true
+			}
+		})
+		route := c.Route().Path
+		if !handlerRoutes[c.Route().Method+" "+route] {
+			route = unmatchedRoute
+		}
+
+		method := c.Method()
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(c.Response().StatusCode())).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
@@ -0,0 +1,65 @@
+// Package metrics mengumpulkan metrik Prometheus aplikasi (HTTP, query GORM, pool koneksi
+// database dan counter domain) di satu registry dan menyajikannya lewat Handler.
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace adalah prefix semua metrik milik aplikasi (HTTP, query dan counter domain);
+// metrik bawaan collector (go_*, process_*, go_sql_*) tetap memakai nama standarnya
+const namespace = "crowdfunding"
+
+// Registry berisi semua metrik aplikasi beserta metrik runtime Go dan proses
+var Registry = prometheus.NewRegistry()
+
+// Counter domain, dinaikkan setelah data berhasil disimpan
+var (
+	ProjectsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "projects_created_total",
+		Help:      "Number of projects created.",
+	})
+	CommentsPosted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "comments_posted_total",
+		Help:      "Number of comments and replies posted.",
+	})
+	// InvestmentsRecorded dinaikkan di setiap jalur yang menulis entri ledger investasi: handler
+	// untuk entri manual, indexer untuk entri on-chain (entri duplikat yang diabaikan tidak dihitung)
+	InvestmentsRecorded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "investments_recorded_total",
+		Help:      "Number of investment ledger entries recorded, by source (manual or indexed).",
+	}, []string{"source"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		dbQueryDuration,
+		ProjectsCreated,
+		CommentsPosted,
+		InvestmentsRecorded,
+	)
+}
+
+// RegisterDB menambahkan statistik pool koneksi (sql.DB.Stats) sebagai metrik go_sql_*
+// dengan label db_name
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler menyajikan isi Registry dalam format exposition Prometheus
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
@@ -6,22 +6,44 @@ import (
 	"time"
 
 	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 // Project merepresentasikan tabel projects
 type Project struct {
-	ID                      uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
-	CreatorWalletAddress    string         `gorm:"type:varchar(42);not null" json:"creator_wallet_address"`
-	Title                   string         `gorm:"type:varchar(255);not null" json:"title"`
-	Description             string         `gorm:"type:text" json:"description"`
-	CoverImageURL           string         `gorm:"type:varchar(255)" json:"cover_image_url"`
-	DeveloperName           string         `gorm:"type:varchar(100)" json:"developer_name"`
-	Genre                   string         `gorm:"type:varchar(50)" json:"genre"`
-	GameType                string         `gorm:"type:varchar(10)" json:"game_type"`
-	InvestorWalletAddresses StringArray    `gorm:"type:text[]" json:"investor_wallet_addresses" swaggertype:"[]string"` // Array of investor wallet addresses
-	Links                   []ExternalLink `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"links,omitempty"`
-	CreatedAt               time.Time      `json:"created_at"`
-	UpdatedAt               time.Time      `json:"updated_at"`
+	ID                      uint64           `gorm:"primaryKey;autoIncrement;index:idx_projects_created_at_id,priority:2" json:"id"`
+	CreatorWalletAddress    string           `gorm:"type:varchar(42);not null;index" json:"creator_wallet_address"`
+	Title                   string           `gorm:"type:varchar(255);not null" json:"title"`
+	Description             string           `gorm:"type:text" json:"description"`
+	CoverImageURL           string           `gorm:"type:varchar(255)" json:"cover_image_url"`
+	DeveloperName           string           `gorm:"type:varchar(100)" json:"developer_name"`
+	Genre                   string           `gorm:"type:varchar(50)" json:"genre"`
+	GameType                string           `gorm:"type:varchar(10)" json:"game_type"`
+	GoalAmount              *string          `gorm:"type:numeric(78,0)" json:"goal_amount"`                              // Target dalam satuan terkecil token (wei)
+	Currency                string           `gorm:"type:varchar(10);not null;default:'ETH'" json:"currency"`            // Simbol mata uang, mis. ETH, USDC
+	CurrencyTokenAddress    string           `gorm:"type:varchar(42);not null;default:''" json:"currency_token_address"` // Kosong untuk native coin
+	CurrencyChainID         int64            `gorm:"not null;default:1" json:"currency_chain_id"`                        // Chain tempat mata uang project berada
+	StartDate               *time.Time       `json:"start_date"`
+	EndDate                 *time.Time       `json:"end_date"`                                                      // Deadline kampanye
+	Status                  string           `gorm:"type:varchar(16);not null;default:'draft';index" json:"status"` // Lihat ProjectStatus*; hanya berubah lewat ProjectRepository.TransitionStatus
+	StatusChangedAt         *time.Time       `json:"status_changed_at"`
+	InvestorWalletAddresses StringArray      `gorm:"-" json:"investor_wallet_addresses" swaggertype:"[]string"` // Diturunkan dari tabel investments (distinct wallet)
+	FundingProgress         *FundingProgress `gorm:"-" json:"funding_progress,omitempty"`                       // Dihitung dari ledger investasi
+	Links                   []ExternalLink   `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"links,omitempty"`
+	Version                 uint64           `gorm:"not null;default:1" json:"version"` // Naik setiap kali project atau link-nya berubah; dipakai sebagai ETag
+	CreatedAt               time.Time        `gorm:"index:idx_projects_created_at_id,priority:1" json:"created_at"`
+	UpdatedAt               time.Time        `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt   `gorm:"index" json:"-"` // Tombstone soft delete; baris dipurge setelah masa retensi
+}
+
+// FundingProgress adalah ringkasan progres pendanaan sebuah project yang dihitung saat response dibuat
+type FundingProgress struct {
+	Raised               string   `json:"raised"`                 // Total bersih (investasi - refund) dalam mata uang project
+	Goal                 *string  `json:"goal"`                   // Sama dengan Project.GoalAmount
+	Percent              *float64 `json:"percent"`                // Raised / Goal * 100, nil jika project tidak punya target
+	BackerCount          int      `json:"backer_count"`           // Jumlah wallet investor unik
+	TimeRemainingSeconds *int64   `json:"time_remaining_seconds"` // Sisa waktu hingga EndDate, nil jika tanpa deadline
+	Ended                bool     `json:"ended"`
 }
 
 // StringArray is a thin alias over pq.StringArray that implements
@@ -62,6 +84,10 @@ func (a *StringArray) UnmarshalJSON(b []byte) error {
 
 // IDs are auto-generated by the database (auto-increment)
 
+// KYCStatusUnverified adalah status KYC awal setiap profil. Status KYC hanya diubah oleh
+// server (proses verifikasi), tidak pernah dari body request PUT /profiles.
+const KYCStatusUnverified = "unverified"
+
 // UserProfile merepresentasikan tabel user_profiles
 type UserProfile struct {
 	WalletAddress   string    `gorm:"type:varchar(42);primaryKey" json:"wallet_address"`
@@ -75,27 +101,114 @@ type UserProfile struct {
 
 // Comment merepresentasikan tabel comments
 type Comment struct {
-	ID                  uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
-	ProjectID           uint64    `gorm:"not null;index" json:"project_id"`
-	AuthorWalletAddress string    `gorm:"type:varchar(42);not null" json:"author_wallet_address"`
-	ParentCommentID     *uint64   `gorm:"index" json:"parent_comment_id"`
-	Content             string    `gorm:"type:text;not null" json:"content"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	ID                  uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProjectID           uint64           `gorm:"not null;index" json:"project_id"`
+	AuthorWalletAddress string           `gorm:"type:varchar(42);not null" json:"author_wallet_address"`
+	ParentCommentID     *uint64          `gorm:"index" json:"parent_comment_id"`
+	Content             string           `gorm:"type:text;not null" json:"content"`
+	Signature           string           `gorm:"type:varchar(132);not null;default:''" json:"signature"` // EIP-191 signature atas auth.CommentSigningMessage
+	EditedAt            *time.Time       `json:"edited_at"`                                              // Terisi jika konten pernah diedit; revisi lama ada di comment_revisions
+	DeletedAt           *time.Time       `json:"deleted_at,omitempty"`                                   // Terisi jika komentar dihapus tetapi dipertahankan sebagai placeholder
+	HiddenAt            *time.Time       `json:"hidden_at,omitempty"`                                    // Terisi jika disembunyikan moderator; hanya terlihat oleh moderator
+	HiddenBy            *string          `gorm:"type:varchar(42)" json:"hidden_by,omitempty"`
+	Reactions           map[string]int64 `gorm:"-" json:"reactions"`                  // Jumlah reaksi per jenis
+	Score               int64            `gorm:"-" json:"score"`                      // Jumlah reaksi berbobot (lihat CommentReactionWeights)
+	ViewerReactions     []string         `gorm:"-" json:"viewer_reactions,omitempty"` // Reaksi wallet yang sedang login
+	CreatedAt           time.Time        `json:"created_at"`
+	UpdatedAt           time.Time        `json:"updated_at"`
 
 	// Relasi
 	Project       Project  `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
 	ParentComment *Comment `gorm:"foreignKey:ParentCommentID" json:"-"`
 }
 
+// DeletedCommentContent adalah konten placeholder untuk komentar terhapus yang masih punya balasan
+const DeletedCommentContent = "[deleted]"
+
+// CommentRevision merepresentasikan tabel comment_revisions (konten komentar sebelum diedit)
+type CommentRevision struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	CommentID uint64    `gorm:"not null;index" json:"comment_id"`
+	Content   string    `gorm:"type:text;not null" json:"content"`
+	Signature string    `gorm:"type:varchar(132);not null;default:''" json:"signature"`
+	EditedAt  time.Time `gorm:"not null" json:"edited_at"` // Waktu revisi ini digantikan
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relasi
+	Comment Comment `gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CommentReaction merepresentasikan tabel comment_reactions (satu reaksi per wallet per jenis)
+type CommentReaction struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	CommentID     uint64    `gorm:"not null;index" json:"comment_id"`
+	WalletAddress string    `gorm:"type:varchar(42);not null" json:"wallet_address"`
+	Type          string    `gorm:"type:varchar(16);not null" json:"type"` // Salah satu key CommentReactionWeights
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Relasi
+	Comment Comment `gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CommentReactionWeights adalah jenis reaksi yang didukung beserta bobotnya dalam skor komentar.
+// Upvote menandai pertanyaan/komentar yang berguna sehingga bobotnya lebih besar.
+var CommentReactionWeights = map[string]int64{
+	"upvote": 2,
+	"like":   1,
+	"heart":  1,
+	"fire":   1,
+	"laugh":  1,
+}
+
+// CommentReport merepresentasikan tabel comment_reports (laporan komentar dari user)
+type CommentReport struct {
+	ID                    uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CommentID             uint64     `gorm:"not null;index" json:"comment_id"`
+	ReporterWalletAddress string     `gorm:"type:varchar(42);not null" json:"reporter_wallet_address"`
+	Reason                string     `gorm:"type:varchar(500);not null" json:"reason"`
+	Resolution            *string    `gorm:"type:varchar(16)" json:"resolution"` // CommentReportResolution*, nil selama laporan masih terbuka
+	ResolvedBy            *string    `gorm:"type:varchar(42)" json:"resolved_by"`
+	ResolvedAt            *time.Time `json:"resolved_at"`
+	CreatedAt             time.Time  `json:"created_at"`
+
+	// Relasi
+	Comment Comment `gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// Hasil penyelesaian laporan komentar
+const (
+	CommentReportResolutionHidden    = "hidden"
+	CommentReportResolutionDeleted   = "deleted"
+	CommentReportResolutionDismissed = "dismissed"
+)
+
+// ReportedComment adalah satu entri antrian moderasi: komentar beserta laporan terbukanya
+type ReportedComment struct {
+	Comment        Comment         `json:"comment"`
+	ReportCount    int64           `json:"report_count"`
+	LastReportedAt time.Time       `json:"last_reported_at"`
+	Reports        []CommentReport `json:"reports"`
+}
+
+// CommentNode adalah komentar beserta balasannya dalam response pohon komentar
+type CommentNode struct {
+	Comment
+	Depth         int           `json:"depth"`                    // 0 untuk komentar top-level
+	ReplyCount    int64         `json:"reply_count"`              // Jumlah seluruh balasan langsung, termasuk yang belum dimuat
+	Replies       []CommentNode `json:"replies"`                  // Dibatasi per level, terlama lebih dulu
+	RepliesCursor string        `json:"replies_cursor,omitempty"` // Cursor untuk memuat balasan berikutnya
+}
+
 // IDs are auto-generated by the database (auto-increment)
 
 // ExternalLink merepresentasikan tabel external_links
 type ExternalLink struct {
 	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
 	ProjectID uint64    `gorm:"not null;index" json:"project_id"`
-	Name      string    `gorm:"type:varchar(50);not null" json:"name"` // e.g., "Instagram", "Twitter", "Website"
-	URL       string    `gorm:"type:varchar(500);not null" json:"url"` // The actual URL
+	Name      string    `gorm:"type:varchar(50);not null" json:"name"`                 // e.g., "Instagram", "Twitter", "Website"
+	Type      string    `gorm:"type:varchar(20);not null;default:'other'" json:"type"` // Salah satu ExternalLinkTypes
+	URL       string    `gorm:"type:varchar(500);not null" json:"url"`                 // The actual URL (http/https saja)
+	Position  int       `gorm:"not null;default:0" json:"position"`                    // Urutan tampilan, kecil lebih dulu
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -103,4 +216,129 @@ type ExternalLink struct {
 	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
+// ExternalLinkTypes adalah jenis platform yang dikenal untuk ExternalLink.Type.
+// Tag validate "oneof" pada request link harus memuat daftar yang sama.
+var ExternalLinkTypes = []string{
+	"website", "twitter", "discord", "telegram", "steam", "epic", "itchio",
+	"youtube", "twitch", "instagram", "tiktok", "facebook", "reddit", "github", "whitepaper", "other",
+}
+
+// ExternalLinkMaxURLLength sama dengan panjang kolom external_links.url (tag validate "max" pada request link)
+const ExternalLinkMaxURLLength = 500
+
 // IDs are auto-generated by the database (auto-increment)
+
+// AuthNonce merepresentasikan tabel auth_nonces (nonce sekali pakai untuk SIWE)
+type AuthNonce struct {
+	Nonce     string     `gorm:"type:varchar(64);primaryKey" json:"nonce"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ProjectCoOwner merepresentasikan tabel project_co_owners (wallet yang diberi hak kelola oleh creator)
+type ProjectCoOwner struct {
+	ProjectID     uint64    `gorm:"primaryKey" json:"project_id"`
+	WalletAddress string    `gorm:"type:varchar(42);primaryKey" json:"wallet_address"`
+	GrantedBy     string    `gorm:"type:varchar(42);not null" json:"granted_by"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Relasi
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// Investment merepresentasikan tabel investments (ledger investasi per transaksi)
+type Investment struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProjectID     uint64    `gorm:"not null;index" json:"project_id"`
+	WalletAddress string    `gorm:"type:varchar(42);not null" json:"wallet_address"`
+	Kind          string    `gorm:"type:varchar(16);not null;default:'investment'" json:"kind"` // InvestmentKindInvestment atau InvestmentKindRefund
+	Source        string    `gorm:"type:varchar(16);not null;default:'manual'" json:"source"`   // InvestmentSourceManual atau InvestmentSourceIndexed
+	Amount        string    `gorm:"type:numeric(78,0);not null;default:0" json:"amount"`        // Satuan terkecil token (wei) dalam bentuk desimal
+	TokenAddress  string    `gorm:"type:varchar(42);not null;default:''" json:"token_address"`  // Kosong untuk native coin (ETH)
+	ChainID       int64     `gorm:"not null;default:0" json:"chain_id"`
+	TxHash        *string   `gorm:"type:varchar(66)" json:"tx_hash"`
+	LogIndex      uint      `gorm:"not null;default:0" json:"log_index"`
+	BlockNumber   *uint64   `json:"block_number"`
+	InvestedAt    time.Time `gorm:"not null" json:"invested_at"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Relasi
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// Jenis entri ledger investasi
+const (
+	InvestmentKindInvestment = "investment"
+	InvestmentKindRefund     = "refund"
+)
+
+// Asal entri ledger investasi. Hanya entri dari indexer (event kontrak) yang dihitung ke
+// funding_progress dan penentuan funded/failed; entri manual dicatat pemilik project tanpa
+// bukti on-chain dan tidak pernah punya tx_hash.
+const (
+	InvestmentSourceManual  = "manual"
+	InvestmentSourceIndexed = "indexed"
+)
+
+// IndexerCheckpoint merepresentasikan tabel indexer_checkpoints (blok terakhir yang sudah diproses)
+type IndexerCheckpoint struct {
+	Name      string    `gorm:"type:varchar(100);primaryKey" json:"name"`
+	ChainID   int64     `gorm:"not null" json:"chain_id"`
+	LastBlock uint64    `gorm:"not null" json:"last_block"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IndexerPendingEvent merepresentasikan tabel indexer_pending_events: event Invested/Refunded yang
+// project-nya belum ada saat diproses indexer. Event disimpan agar checkpoint tetap bisa maju, lalu
+// dipindahkan ke ledger investasi begitu project-nya dibuat.
+type IndexerPendingEvent struct {
+	ChainID       int64     `gorm:"primaryKey;autoIncrement:false" json:"chain_id"`
+	TxHash        string    `gorm:"type:varchar(66);primaryKey" json:"tx_hash"`
+	LogIndex      uint      `gorm:"primaryKey;autoIncrement:false" json:"log_index"`
+	ProjectID     uint64    `gorm:"not null;index" json:"project_id"`
+	WalletAddress string    `gorm:"type:varchar(42);not null" json:"wallet_address"`
+	Kind          string    `gorm:"type:varchar(16);not null" json:"kind"`
+	Amount        string    `gorm:"type:numeric(78,0);not null" json:"amount"`
+	TokenAddress  string    `gorm:"type:varchar(42);not null;default:''" json:"token_address"`
+	BlockNumber   uint64    `gorm:"not null" json:"block_number"`
+	InvestedAt    time.Time `gorm:"not null" json:"invested_at"` // Timestamp blok
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Investment mengubah event tertunda menjadi entri ledger hasil indexer
+func (e IndexerPendingEvent) Investment() Investment {
+	txHash := e.TxHash
+	blockNumber := e.BlockNumber
+	return Investment{
+		ProjectID:     e.ProjectID,
+		WalletAddress: e.WalletAddress,
+		Kind:          e.Kind,
+		Source:        InvestmentSourceIndexed,
+		Amount:        e.Amount,
+		TokenAddress:  e.TokenAddress,
+		ChainID:       e.ChainID,
+		TxHash:        &txHash,
+		LogIndex:      e.LogIndex,
+		BlockNumber:   &blockNumber,
+		InvestedAt:    e.InvestedAt,
+	}
+}
+
+// InvestmentTotal adalah total nominal investasi untuk satu token pada satu chain
+type InvestmentTotal struct {
+	ChainID      int64  `json:"chain_id"`
+	TokenAddress string `json:"token_address"`
+	Amount       string `json:"amount"`
+}
+
+// InvestmentSummary adalah agregat ledger investasi sebuah project
+type InvestmentSummary struct {
+	ProjectID       uint64            `json:"project_id"`
+	InvestorCount   int64             `json:"investor_count"`
+	InvestmentCount int64             `json:"investment_count"`
+	RefundCount     int64             `json:"refund_count"`
+	Totals          []InvestmentTotal `json:"totals"`
+	FirstInvestedAt *time.Time        `json:"first_invested_at"`
+	LastInvestedAt  *time.Time        `json:"last_invested_at"`
+}
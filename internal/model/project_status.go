@@ -0,0 +1,57 @@
+package model
+
+// Status lifecycle project
+const (
+	ProjectStatusDraft    = "draft"
+	ProjectStatusReview   = "review"
+	ProjectStatusLive     = "live"
+	ProjectStatusFunded   = "funded"
+	ProjectStatusFailed   = "failed"
+	ProjectStatusArchived = "archived"
+)
+
+// projectStatusTransitions adalah satu-satunya sumber transisi status yang diizinkan.
+// funded/failed hanya dicapai dari live setelah deadline lewat (lihat ProjectRepository.SettleEnded).
+var projectStatusTransitions = map[string][]string{
+	ProjectStatusDraft:    {ProjectStatusReview, ProjectStatusArchived},
+	ProjectStatusReview:   {ProjectStatusLive, ProjectStatusArchived},
+	ProjectStatusLive:     {ProjectStatusFunded, ProjectStatusFailed},
+	ProjectStatusFunded:   {ProjectStatusArchived},
+	ProjectStatusFailed:   {ProjectStatusArchived},
+	ProjectStatusArchived: {},
+}
+
+// DefaultPublicProjectStatuses adalah status yang ditampilkan di listing publik jika tidak ada filter status
+var DefaultPublicProjectStatuses = []string{ProjectStatusLive, ProjectStatusFunded, ProjectStatusFailed}
+
+// ValidProjectStatus mengecek apakah status dikenal
+func ValidProjectStatus(status string) bool {
+	_, ok := projectStatusTransitions[status]
+	return ok
+}
+
+// PrivateProjectStatuses adalah status yang hanya terlihat oleh creator dan co-owner
+var PrivateProjectStatuses = []string{ProjectStatusDraft, ProjectStatusReview}
+
+// ProjectStatusIsPublic mengecek apakah project dengan status tersebut boleh dilihat siapa saja.
+// Draft dan review hanya terlihat oleh creator dan co-owner.
+func ProjectStatusIsPublic(status string) bool {
+	return status != ProjectStatusDraft && status != ProjectStatusReview
+}
+
+// CanTransitionProjectStatus mengecek apakah perpindahan status from -> to diizinkan
+func CanTransitionProjectStatus(from, to string) bool {
+	for _, next := range projectStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedProjectStatusTransitions mengembalikan status tujuan yang sah dari status from
+func AllowedProjectStatusTransitions(from string) []string {
+	allowed := make([]string, len(projectStatusTransitions[from]))
+	copy(allowed, projectStatusTransitions[from])
+	return allowed
+}
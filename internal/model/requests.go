@@ -0,0 +1,103 @@
+package model
+
+import "strings"
+
+// Normalize merapikan link sebelum divalidasi: spasi di awal/akhir dibuang dan type di-lowercase
+func (r *ExternalLinkCreate) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Type = strings.ToLower(strings.TrimSpace(r.Type))
+	r.URL = strings.TrimSpace(r.URL)
+}
+
+// ExternalLink membuat ExternalLink dari request. Type kosong menjadi "other";
+// Position nil dibiarkan 0 dan diisi oleh caller.
+func (r ExternalLinkCreate) ExternalLink(projectID uint64) ExternalLink {
+	link := ExternalLink{
+		ProjectID: projectID,
+		Name:      r.Name,
+		Type:      r.Type,
+		URL:       r.URL,
+	}
+	if link.Type == "" {
+		link.Type = "other"
+	}
+	if r.Position != nil {
+		link.Position = *r.Position
+	}
+	return link
+}
+
+// Normalize merapikan project dan semua link di dalamnya
+func (r *ProjectCreate) Normalize() {
+	r.CreatorWalletAddress = strings.TrimSpace(r.CreatorWalletAddress)
+	r.CurrencyTokenAddress = strings.TrimSpace(r.CurrencyTokenAddress)
+	for i := range r.Links {
+		r.Links[i].Normalize()
+	}
+}
+
+// Project membuat Project dari request. Position link mengikuti urutan di array.
+func (r ProjectCreate) Project() Project {
+	project := Project{
+		CreatorWalletAddress: r.CreatorWalletAddress,
+		Title:                r.Title,
+		Description:          r.Description,
+		CoverImageURL:        r.CoverImageURL,
+		DeveloperName:        r.DeveloperName,
+		Genre:                r.Genre,
+		GameType:             r.GameType,
+		GoalAmount:           r.GoalAmount,
+		Currency:             r.Currency,
+		CurrencyTokenAddress: r.CurrencyTokenAddress,
+		CurrencyChainID:      r.CurrencyChainID,
+		StartDate:            r.StartDate,
+		EndDate:              r.EndDate,
+	}
+	for i, l := range r.Links {
+		link := l.ExternalLink(0)
+		link.Position = i
+		project.Links = append(project.Links, link)
+	}
+	return project
+}
+
+// Normalize merapikan links yang dikirim di PATCH
+func (r *ProjectPatch) Normalize() {
+	for i := range r.Links {
+		r.Links[i].Normalize()
+	}
+}
+
+// ExternalLinks membuat links pengganti dari PATCH. Position mengikuti urutan di array.
+func (r ProjectPatch) ExternalLinks(projectID uint64) []ExternalLink {
+	links := make([]ExternalLink, 0, len(r.Links))
+	for i, l := range r.Links {
+		link := l.ExternalLink(projectID)
+		link.Position = i
+		links = append(links, link)
+	}
+	return links
+}
+
+// Normalize membuang spasi di awal/akhir alasan laporan
+func (r *CommentReportRequest) Normalize() {
+	r.Reason = strings.TrimSpace(r.Reason)
+}
+
+// Normalize merapikan field profil
+func (r *UserProfileUpsert) Normalize() {
+	r.Username = strings.TrimSpace(r.Username)
+	r.Email = strings.TrimSpace(r.Email)
+	r.ProfileImageURL = strings.TrimSpace(r.ProfileImageURL)
+}
+
+// UserProfile membuat UserProfile untuk wallet dari request. KYCStatus sengaja dibiarkan
+// kosong: status KYC dikelola server, bukan oleh pemilik profil.
+func (r UserProfileUpsert) UserProfile(walletAddress string) UserProfile {
+	return UserProfile{
+		WalletAddress:   walletAddress,
+		Username:        r.Username,
+		Email:           r.Email,
+		ProfileImageURL: r.ProfileImageURL,
+	}
+}
@@ -6,22 +6,77 @@ import "time"
 // for documentation generation. It mirrors model.Project but uses primitive
 // slice types that swag can parse (e.g., []string for investor addresses).
 type ProjectSwagger struct {
-	ID                      string    `json:"id" example:"0199fb01-ae3c-7c26-b70a-8f221585ccb4"`
-	CreatorWalletAddress    string    `json:"creator_wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
-	Title                   string    `json:"title" example:"Epic RPG Game"`
-	Description             string    `json:"description" example:"An amazing blockchain RPG game"`
-	CoverImageURL           string    `json:"cover_image_url" example:"https://example.com/cover.jpg"`
-	DeveloperName           string    `json:"developer_name" example:"Epic Games Studio"`
-	Genre                   string    `json:"genre" example:"RPG"`
-	GameType                string    `json:"game_type" example:"web3"`
-	InvestorWalletAddresses []string  `json:"investor_wallet_addresses" example:"[\"0xabc...\"]"`
-	CreatedAt               time.Time `json:"created_at"`
-	UpdatedAt               time.Time `json:"updated_at"`
-}
-
-// Generic API responses used for documentation
+	ID                      uint64           `json:"id" example:"1760500000000"`
+	CreatorWalletAddress    string           `json:"creator_wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+	Title                   string           `json:"title" example:"Epic RPG Game"`
+	Description             string           `json:"description" example:"An amazing blockchain RPG game"`
+	CoverImageURL           string           `json:"cover_image_url" example:"https://example.com/cover.jpg"`
+	DeveloperName           string           `json:"developer_name" example:"Epic Games Studio"`
+	Genre                   string           `json:"genre" example:"RPG"`
+	GameType                string           `json:"game_type" example:"web3"`
+	Status                  string           `json:"status" example:"live" enums:"draft,review,live,funded,failed,archived"`
+	StatusChangedAt         *time.Time       `json:"status_changed_at"`
+	GoalAmount              *string          `json:"goal_amount" example:"50000000000000000000"`
+	Currency                string           `json:"currency" example:"ETH"`
+	CurrencyTokenAddress    string           `json:"currency_token_address" example:""`
+	CurrencyChainID         int64            `json:"currency_chain_id" example:"1"` // Only ledger entries on this chain count toward funding_progress
+	StartDate               *time.Time       `json:"start_date"`
+	EndDate                 *time.Time       `json:"end_date"`
+	InvestorWalletAddresses []string         `json:"investor_wallet_addresses" example:"[\"0xabc...\"]"`
+	FundingProgress         *FundingProgress `json:"funding_progress"`
+	Version                 uint64           `json:"version" example:"3"` // Same value as the ETag header
+	CreatedAt               time.Time        `json:"created_at"`
+	UpdatedAt               time.Time        `json:"updated_at"`
+}
+
+// ProjectListResponse is the paginated envelope returned by GET /projects
+type ProjectListResponse struct {
+	Data       []ProjectSwagger `json:"data"`
+	NextCursor string           `json:"next_cursor" example:"eyJzIjoiY3JlYXRlZF9hdCIsImQiOnRydWUsInYiOiIyMDI1LTEwLTE1VDEwOjAwOjAwWiIsImlkIjoxfQ"`
+	Total      int64            `json:"total" example:"42"`
+	Limit      int              `json:"limit" example:"20"`
+}
+
+// ProjectSearchHit is a single ranked full-text search result
+type ProjectSearchHit struct {
+	Project    ProjectSwagger `json:"project"`
+	Rank       float64        `json:"rank" example:"0.6079271"`
+	Highlights struct {
+		Title       string `json:"title" example:"Epic <mark>RPG</mark> Game"`
+		Description string `json:"description" example:"An amazing blockchain <mark>RPG</mark> game"`
+	} `json:"highlights"`
+}
+
+// ProjectSearchResponse is returned by GET /projects/search
+type ProjectSearchResponse struct {
+	Data   []ProjectSearchHit `json:"data"`
+	Total  int64              `json:"total" example:"3"`
+	Limit  int                `json:"limit" example:"20"`
+	Offset int                `json:"offset" example:"0"`
+}
+
+// ErrorResponse documents the application/problem+json (RFC 7807) body returned for every error
 type ErrorResponse struct {
-	Error string `json:"error" example:"Resource not found"`
+	Type      string              `json:"type" example:"about:blank"`
+	Title     string              `json:"title" example:"Not Found"`
+	Status    int                 `json:"status" example:"404"`
+	Detail    string              `json:"detail,omitempty" example:"Project not found"`
+	Instance  string              `json:"instance,omitempty" example:"/api/v1/projects/1"`
+	RequestID string              `json:"request_id,omitempty" example:"3f1c2a9e-5b7d-4e0a-9c1f-2d8e6b4a7c10"`
+	Errors    []ProblemFieldError `json:"errors,omitempty"`
+}
+
+// ProblemFieldError is one per-field validation issue in ErrorResponse.errors
+type ProblemFieldError struct {
+	Field   string `json:"field" example:"links[0].url"`
+	Message string `json:"message" example:"url must use http or https"`
+}
+
+// StatusTransitionError is returned when a project lifecycle transition is not allowed
+type StatusTransitionError struct {
+	ErrorResponse
+	CurrentStatus      string   `json:"current_status" example:"live"`
+	AllowedTransitions []string `json:"allowed_transitions" example:"funded,failed"`
 }
 
 type GenericMessage struct {
@@ -30,43 +85,160 @@ type GenericMessage struct {
 
 // Request/Response models
 type AddInvestorRequest struct {
-	WalletAddress string `json:"wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+	WalletAddress string `json:"wallet_address" validate:"required,wallet" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
 }
 
 type InvestorsResponse struct {
 	Investors []string `json:"investors" example:"[\"0x111...\", \"0x222...\"]"`
 }
 
-// ProjectPatch is used for PATCH /projects/{id} - all fields optional
+// InvestmentCreateRequest is the body for recording a manual (off-chain) investment in the ledger.
+// On-chain fields (chain_id, tx_hash, log_index, block_number) are only set by the indexer.
+type InvestmentCreateRequest struct {
+	WalletAddress string    `json:"wallet_address" validate:"required,wallet" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+	Kind          string    `json:"kind,omitempty" validate:"omitempty,oneof=investment refund" example:"investment" enums:"investment,refund"`
+	Amount        string    `json:"amount" validate:"required,max=78" example:"1500000000000000000"`
+	TokenAddress  string    `json:"token_address,omitempty" validate:"omitempty,wallet" example:""`
+	InvestedAt    time.Time `json:"invested_at,omitempty"`
+}
+
+// InvestmentListResponse is the paginated envelope returned by GET /projects/{id}/investments
+type InvestmentListResponse struct {
+	Data   []Investment `json:"data"`
+	Total  int64        `json:"total" example:"12"`
+	Limit  int          `json:"limit" example:"20"`
+	Offset int          `json:"offset" example:"0"`
+}
+
+// ProjectPatch is the JSON Merge Patch (RFC 7396) body for PATCH /projects/{id}. Omitted fields are
+// left unchanged, null clears a field (title cannot be cleared) and links replaces the whole list
 type ProjectPatch struct {
-	Title         *string `json:"title,omitempty" example:"Updated Game Title"`
-	Description   *string `json:"description,omitempty" example:"Updated description"`
-	CoverImageURL *string `json:"cover_image_url,omitempty" example:"https://example.com/new-image.jpg"`
-	DeveloperName *string `json:"developer_name,omitempty" example:"New Studio Name"`
-	Genre         *string `json:"genre,omitempty" example:"Action RPG"`
-	GameType      *string `json:"game_type,omitempty" example:"web3"`
+	Title         *string              `json:"title,omitempty" validate:"nonblank,max=255" example:"Updated Game Title"`
+	Description   *string              `json:"description,omitempty" example:"Updated description"`
+	CoverImageURL *string              `json:"cover_image_url,omitempty" validate:"omitempty,url,max=255" example:"https://example.com/new-image.jpg"`
+	DeveloperName *string              `json:"developer_name,omitempty" validate:"max=100" example:"New Studio Name"`
+	Genre         *string              `json:"genre,omitempty" validate:"max=50" example:"Action RPG"`
+	GameType      *string              `json:"game_type,omitempty" validate:"max=10" example:"web3"`
+	GoalAmount    *string              `json:"goal_amount,omitempty" validate:"nonblank,max=78" example:"75000000000000000000"`
+	EndDate       *string              `json:"end_date,omitempty" validate:"nonblank,rfc3339" example:"2026-12-31T23:59:59Z"`
+	Links         []ExternalLinkCreate `json:"links,omitempty"` // Replaces every link; null or [] removes them all
 }
 
 // ProjectCreate represents fields required to create a project (request body)
 type ProjectCreate struct {
-	CreatorWalletAddress string `json:"creator_wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
-	Title                string `json:"title" example:"My Awesome Game"`
-	Description          string `json:"description,omitempty" example:"This is an amazing Web3 game"`
-	CoverImageURL        string `json:"cover_image_url,omitempty" example:"https://example.com/image.jpg"`
-	DeveloperName        string `json:"developer_name,omitempty" example:"GameDev Studios"`
-	Genre                string `json:"genre,omitempty" example:"RPG"`
-	GameType             string `json:"game_type,omitempty" example:"web3"`
+	CreatorWalletAddress string               `json:"creator_wallet_address" validate:"omitempty,wallet" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+	Title                string               `json:"title" validate:"required,max=255" example:"My Awesome Game"`
+	Description          string               `json:"description,omitempty" example:"This is an amazing Web3 game"`
+	CoverImageURL        string               `json:"cover_image_url,omitempty" validate:"omitempty,url,max=255" example:"https://example.com/image.jpg"`
+	DeveloperName        string               `json:"developer_name,omitempty" validate:"max=100" example:"GameDev Studios"`
+	Genre                string               `json:"genre,omitempty" validate:"max=50" example:"RPG"`
+	GameType             string               `json:"game_type,omitempty" validate:"max=10" example:"web3"`
+	GoalAmount           *string              `json:"goal_amount,omitempty" validate:"nonblank,max=78" example:"50000000000000000000"` // Satuan terkecil token (wei), harus > 0
+	Currency             string               `json:"currency,omitempty" validate:"max=10" example:"ETH"`
+	CurrencyTokenAddress string               `json:"currency_token_address,omitempty" validate:"omitempty,wallet" example:""` // Kosong untuk native coin
+	CurrencyChainID      int64                `json:"currency_chain_id,omitempty" validate:"min=0" example:"1"`                // Default 1 (Ethereum mainnet); hanya ledger di chain ini yang dihitung ke funding
+	StartDate            *time.Time           `json:"start_date,omitempty"`
+	EndDate              *time.Time           `json:"end_date,omitempty"` // Harus di masa depan
+	Links                []ExternalLinkCreate `json:"links,omitempty"`    // Position mengikuti urutan array
 }
 
 // ExternalLinkCreate represents fields required to create/update an external link
 type ExternalLinkCreate struct {
-	Name string `json:"name" example:"Instagram"`
-	URL  string `json:"url" example:"https://instagram.com/mygame"`
+	Name     string `json:"name" validate:"required,max=50" example:"Instagram"`
+	Type     string `json:"type,omitempty" validate:"omitempty,oneof=website twitter discord telegram steam epic itchio youtube twitch instagram tiktok facebook reddit github whitepaper other" example:"instagram" enums:"website,twitter,discord,telegram,steam,epic,itchio,youtube,twitch,instagram,tiktok,facebook,reddit,github,whitepaper,other"`
+	URL      string `json:"url" validate:"required,max=500,url" example:"https://instagram.com/mygame"` // http/https saja, maks 500 karakter
+	Position *int   `json:"position,omitempty" validate:"min=0" example:"0"`                            // Default: akhir daftar
 }
 
 // CommentCreate represents fields required to create a comment
 type CommentCreate struct {
-	AuthorWalletAddress string  `json:"author_wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
-	Content             string  `json:"content" example:"This project looks amazing!"`
-	ParentCommentID     *string `json:"parent_comment_id,omitempty" example:"0199fb01-b44b-7a26-9625-3be2baf2d905"`
+	AuthorWalletAddress string  `json:"author_wallet_address" validate:"required,wallet" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+	Content             string  `json:"content" validate:"required" example:"This project looks amazing!"`
+	ParentCommentID     *uint64 `json:"parent_comment_id,omitempty" example:"1760500000000"`
+	Signature           string  `json:"signature" validate:"required,max=132" example:"0x5d2f...1b"` // personal_sign over the comment signing message
+}
+
+// CommentUpdate is the body for editing a comment; signature covers the new content
+type CommentUpdate struct {
+	Content   string `json:"content" validate:"required" example:"This project looks amazing! (edited)"`
+	Signature string `json:"signature" validate:"required,max=132" example:"0x5d2f...1b"`
+}
+
+// CommentReactionsResponse is the reaction summary of a comment after adding or removing a reaction
+type CommentReactionsResponse struct {
+	CommentID       uint64           `json:"comment_id" example:"1760500000001"`
+	Reactions       map[string]int64 `json:"reactions"`
+	Score           int64            `json:"score" example:"5"`
+	ViewerReactions []string         `json:"viewer_reactions" example:"upvote"`
+}
+
+// CommentReportRequest is the body for reporting a comment
+type CommentReportRequest struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"Spam link to a phishing site"`
+}
+
+// ModerationQueueResponse is the paginated envelope returned by the comment moderation queue
+type ModerationQueueResponse struct {
+	Data   []ReportedComment `json:"data"`
+	Total  int64             `json:"total" example:"4"`
+	Limit  int               `json:"limit" example:"20"`
+	Offset int               `json:"offset" example:"0"`
+}
+
+// CommentTreeResponse is returned by GET /projects/{id}/comments?format=tree
+type CommentTreeResponse struct {
+	Data       []CommentNode `json:"data"`
+	NextCursor string        `json:"next_cursor" example:"eyJkIjowLCJhIjp7InQiOiIyMDI1LTEwLTE1VDEwOjAwOjAwWiIsImlkIjoxfX0"`
+}
+
+// CommentSignatureVerification describes how a stored comment signature can be re-verified
+type CommentSignatureVerification struct {
+	CommentID           uint64 `json:"comment_id" example:"1760500000001"`
+	AuthorWalletAddress string `json:"author_wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+	SignedMessage       string `json:"signed_message" example:"Web3 Crowdfunding Comment\nProject ID: 1760500000000\nParent ID: none\nContent:\nThis project looks amazing!"`
+	Signature           string `json:"signature" example:"0x5d2f...1b"`
+	Valid               bool   `json:"valid" example:"true"`
+}
+
+// NonceResponse is returned by GET /auth/nonce
+type NonceResponse struct {
+	Nonce     string    `json:"nonce" example:"9f1c2a7b4e6d8f00a1b2c3d4e5f60718"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SIWEVerifyRequest carries the signed EIP-4361 message and its personal_sign signature
+type SIWEVerifyRequest struct {
+	Message   string `json:"message" validate:"required" example:"localhost:3000 wants you to sign in with your Ethereum account:\n0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb\n\nSign in to Web3 Crowdfunding\n\nURI: http://localhost:3000\nVersion: 1\nChain ID: 1\nNonce: 9f1c2a7b4e6d8f00a1b2c3d4e5f60718\nIssued At: 2025-10-15T10:00:00Z"`
+	Signature string `json:"signature" validate:"required" example:"0x5d2f...1b"`
+}
+
+// SessionResponse is returned after a successful SIWE verification
+type SessionResponse struct {
+	Token         string    `json:"token" example:"eyJzdWIiOiIweDc0MmQzNUNj...Zq3w"`
+	WalletAddress string    `json:"wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// AuthMeResponse describes the wallet attached to the current session
+type AuthMeResponse struct {
+	WalletAddress string `json:"wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+}
+
+// CoOwnerRequest is the body for granting a co-owner on a project
+type CoOwnerRequest struct {
+	WalletAddress string `json:"wallet_address" validate:"required,wallet" example:"0x1234567890abcdef1234567890abcdef12345678"`
+}
+
+// UserProfileUpsert is the body for creating or updating the authenticated wallet's profile.
+// kyc_status is managed by the server and cannot be set here
+type UserProfileUpsert struct {
+	Username        string `json:"username" validate:"required,max=50" example:"satoshi"`
+	Email           string `json:"email" validate:"required,max=255,email" example:"satoshi@example.com"`
+	ProfileImageURL string `json:"profile_image_url,omitempty" validate:"omitempty,url,max=255" example:"https://example.com/avatar.png"`
+}
+
+// OwnersResponse lists everyone allowed to modify a project
+type OwnersResponse struct {
+	CreatorWalletAddress string           `json:"creator_wallet_address" example:"0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb"`
+	CoOwners             []ProjectCoOwner `json:"co_owners"`
 }
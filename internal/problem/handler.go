@@ -0,0 +1,51 @@
+package problem
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+)
+
+// errorProblems memetakan jenis error domain ke status HTTP dan type problem
+var errorProblems = map[error]struct {
+	status int
+	typ    string
+}{
+	apperror.ErrNotFound:   {fiber.StatusNotFound, TypeNotFound},
+	apperror.ErrConflict:   {fiber.StatusConflict, TypeConflict},
+	apperror.ErrValidation: {fiber.StatusBadRequest, TypeValidation},
+	apperror.ErrForbidden:  {fiber.StatusForbidden, TypeForbidden},
+
+	apperror.ErrPreconditionFailed: {fiber.StatusPreconditionFailed, TypePreconditionFailed},
+}
+
+// ErrorHandler adalah satu-satunya tempat error yang dikembalikan handler diubah menjadi
+// response HTTP, selalu dalam format application/problem+json. *Problem ditulis
+// apa adanya, error domain (apperror) dipetakan lewat errorProblems, *fiber.Error memakai
+// kodenya sendiri, dan error lain menjadi 500 tanpa membocorkan pesan aslinya.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	var p *Problem
+	var fe *fiber.Error
+	switch e := apperror.As(err); {
+	case errors.As(err, &p):
+	case e != nil:
+		mapping, ok := errorProblems[e.Kind]
+		if !ok {
+			mapping.status, mapping.typ = fiber.StatusInternalServerError, TypeBlank
+		}
+		p = New(mapping.status, e.Message)
+		p.Type = mapping.typ
+		p.Errors = e.Fields
+		for key, value := range e.Details {
+			p.With(key, value)
+		}
+	case errors.As(err, &fe):
+		p = New(fe.Code, fe.Message)
+	default:
+		slog.ErrorContext(c.UserContext(), "unhandled error", "method", c.Method(), "path", c.Path(), "error", err)
+		p = New(fiber.StatusInternalServerError, "Internal server error")
+	}
+	return Write(c, p)
+}
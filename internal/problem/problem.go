@@ -0,0 +1,105 @@
+// Package problem menulis response error dalam format RFC 7807 (application/problem+json).
+// Handler cukup mengembalikan *Problem (atau error apperror); ErrorHandler Fiber yang menulisnya.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+)
+
+// ContentType adalah media type response problem
+const ContentType = "application/problem+json"
+
+// URI type untuk jenis masalah yang punya arti khusus. Masalah lain memakai "about:blank",
+// yang berarti title sama dengan teks status HTTP.
+const (
+	TypeBlank      = "about:blank"
+	TypeValidation = "urn:problem-type:validation-error"
+	TypeNotFound   = "urn:problem-type:not-found"
+	TypeConflict   = "urn:problem-type:conflict"
+	TypeForbidden  = "urn:problem-type:forbidden"
+
+	TypePreconditionFailed = "urn:problem-type:precondition-failed"
+)
+
+// Problem adalah response error RFC 7807. Extensions ditulis sebagai member tambahan
+// di level atas objek JSON.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	RequestID  string
+	Errors     []apperror.FieldError
+	Extensions map[string]interface{}
+}
+
+// New membuat Problem "about:blank" dengan title dari teks status HTTP
+func New(status int, detail string) *Problem {
+	return &Problem{
+		Type:   TypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Error membuat *Problem bisa dikembalikan langsung dari handler Fiber
+func (p *Problem) Error() string {
+	return p.Detail
+}
+
+// With menambahkan member extension dan mengembalikan Problem yang sama
+func (p *Problem) With(key string, value interface{}) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+// MarshalJSON menulis member standar lalu extension; extension tidak bisa menimpa member standar
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	body := make(map[string]interface{}, len(p.Extensions)+7)
+	for key, value := range p.Extensions {
+		body[key] = value
+	}
+	body["type"] = p.Type
+	body["title"] = p.Title
+	body["status"] = p.Status
+	if p.Detail != "" {
+		body["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		body["instance"] = p.Instance
+	}
+	if p.RequestID != "" {
+		body["request_id"] = p.RequestID
+	}
+	if len(p.Errors) > 0 {
+		body["errors"] = p.Errors
+	}
+	return json.Marshal(body)
+}
+
+// Write menulis Problem ke response. Instance diisi path request dan RequestID diambil
+// dari header X-Request-ID response jika belum diisi.
+func Write(c *fiber.Ctx, p *Problem) error {
+	if p.Instance == "" {
+		p.Instance = c.Path()
+	}
+	if p.RequestID == "" {
+		p.RequestID = c.GetRespHeader(fiber.HeaderXRequestID)
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, ContentType)
+	return c.Status(p.Status).Send(body)
+}
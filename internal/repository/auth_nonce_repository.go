@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"gorm.io/gorm"
+)
+
+// AuthNonceRepository menangani operasi database untuk nonce SIWE
+type AuthNonceRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthNonceRepository membuat instance baru dari AuthNonceRepository
+func NewAuthNonceRepository(db *gorm.DB) *AuthNonceRepository {
+	return &AuthNonceRepository{db: db}
+}
+
+// WithContext mengembalikan repository yang menjalankan query dengan ctx, sehingga
+// pembatalan request dan request ID ikut sampai ke query dan log SQL
+func (r *AuthNonceRepository) WithContext(ctx context.Context) AuthNonceStore {
+	return &AuthNonceRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create menyimpan nonce baru
+func (r *AuthNonceRepository) Create(nonce *model.AuthNonce) error {
+	return translateError(r.db.Create(nonce).Error)
+}
+
+// Consume menandai nonce sebagai terpakai secara atomik.
+// Mengembalikan false jika nonce tidak ada, sudah dipakai, atau kadaluarsa.
+func (r *AuthNonceRepository) Consume(nonce string) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.AuthNonce{}).
+		Where("nonce = ? AND used_at IS NULL AND expires_at > ?", nonce, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
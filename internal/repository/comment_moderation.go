@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ModerationQueueParams berisi filter dan pagination antrian moderasi komentar
+type ModerationQueueParams struct {
+	ProjectID *uint64 // nil untuk semua project (khusus admin)
+	Limit     int
+	Offset    int
+}
+
+// Report mencatat laporan komentar. Wallet yang laporannya masih terbuka untuk komentar
+// yang sama tidak bisa melapor lagi.
+func (r *CommentRepository) Report(report *model.CommentReport) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlreadyReported
+	}
+	return nil
+}
+
+// ModerationQueue mengambil komentar yang punya laporan terbuka, paling banyak dilaporkan lebih dulu
+func (r *CommentRepository) ModerationQueue(params ModerationQueueParams) ([]model.ReportedComment, int64, error) {
+	query := r.db.Model(&model.Comment{}).
+		Joins("JOIN comment_reports cr ON cr.comment_id = comments.id AND cr.resolved_at IS NULL")
+	if params.ProjectID != nil {
+		query = query.Where("comments.project_id = ?", *params.ProjectID)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Distinct("comments.id").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		ID             uint64
+		ReportCount    int64
+		LastReportedAt time.Time
+	}
+	err := query.
+		Select("comments.id, COUNT(cr.id) AS report_count, MAX(cr.created_at) AS last_reported_at").
+		Group("comments.id").
+		Order("report_count DESC, last_reported_at DESC, comments.id DESC").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(rows) == 0 {
+		return []model.ReportedComment{}, total, nil
+	}
+
+	ids := make([]uint64, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	var comments []model.Comment
+	if err := r.db.Where("id IN ?", ids).Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint64]model.Comment, len(comments))
+	for _, c := range comments {
+		byID[c.ID] = c
+	}
+
+	var reports []model.CommentReport
+	if err := r.db.Where("comment_id IN ? AND resolved_at IS NULL", ids).Order("created_at ASC, id ASC").Find(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+	reportsByComment := make(map[uint64][]model.CommentReport, len(rows))
+	for _, report := range reports {
+		reportsByComment[report.CommentID] = append(reportsByComment[report.CommentID], report)
+	}
+
+	queue := make([]model.ReportedComment, 0, len(rows))
+	for _, row := range rows {
+		comment, ok := byID[row.ID]
+		if !ok {
+			continue
+		}
+		queue = append(queue, model.ReportedComment{
+			Comment:        comment,
+			ReportCount:    row.ReportCount,
+			LastReportedAt: row.LastReportedAt,
+			Reports:        reportsByComment[row.ID],
+		})
+	}
+	return queue, total, nil
+}
+
+// SetHidden menyembunyikan atau menampilkan kembali komentar. Menyembunyikan komentar
+// sekaligus menyelesaikan laporan terbukanya.
+func (r *CommentRepository) SetHidden(commentID uint64, hidden bool, moderator string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"hidden_at": nil, "hidden_by": nil}
+		if hidden {
+			updates = map[string]interface{}{"hidden_at": time.Now(), "hidden_by": moderator}
+		}
+		if err := tx.Model(&model.Comment{}).Where("id = ?", commentID).Updates(updates).Error; err != nil {
+			return err
+		}
+		if !hidden {
+			return nil
+		}
+		return resolveReports(tx, commentID, model.CommentReportResolutionHidden, moderator)
+	})
+}
+
+// ResolveReports menyelesaikan semua laporan terbuka sebuah komentar
+func (r *CommentRepository) ResolveReports(commentID uint64, resolution, moderator string) error {
+	return resolveReports(r.db, commentID, resolution, moderator)
+}
+
+func resolveReports(db *gorm.DB, commentID uint64, resolution, moderator string) error {
+	return db.Model(&model.CommentReport{}).
+		Where("comment_id = ? AND resolved_at IS NULL", commentID).
+		Updates(map[string]interface{}{
+			"resolution":  resolution,
+			"resolved_by": moderator,
+			"resolved_at": time.Now(),
+		}).Error
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"gorm.io/gorm/clause"
+)
+
+// Urutan listing komentar flat
+const (
+	CommentSortNew = "new" // terbaru lebih dulu
+	CommentSortTop = "top" // skor reaksi tertinggi lebih dulu
+)
+
+// CommentListParams berisi opsi listing komentar flat
+type CommentListParams struct {
+	Sort   string // CommentSortNew (default) atau CommentSortTop
+	Viewer string // wallet yang sedang login, untuk mengisi ViewerReactions
+
+	IncludeHidden bool // sertakan komentar yang disembunyikan moderator
+}
+
+// AddReaction menambahkan reaksi. Reaksi yang sudah ada (wallet dan jenis yang sama) dibiarkan;
+// mengembalikan true jika reaksi baru tercatat.
+func (r *CommentRepository) AddReaction(reaction *model.CommentReaction) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+	return result.RowsAffected > 0, translateError(result.Error)
+}
+
+// RemoveReaction menghapus reaksi wallet; mengembalikan false jika reaksi tidak ada
+func (r *CommentRepository) RemoveReaction(commentID uint64, walletAddress, reactionType string) (bool, error) {
+	result := r.db.
+		Where("comment_id = ? AND LOWER(wallet_address) = LOWER(?) AND type = ?", commentID, walletAddress, reactionType).
+		Delete(&model.CommentReaction{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// AttachReactions mengisi Reactions, Score dan ViewerReactions untuk setiap komentar
+func (r *CommentRepository) AttachReactions(comments []*model.Comment, viewer string) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+
+	var counts []struct {
+		CommentID uint64
+		Type      string
+		Count     int64
+	}
+	err := r.db.Model(&model.CommentReaction{}).
+		Select("comment_id, type, COUNT(*) AS count").
+		Where("comment_id IN ?", ids).
+		Group("comment_id, type").
+		Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	byComment := make(map[uint64]map[string]int64, len(comments))
+	for _, row := range counts {
+		if byComment[row.CommentID] == nil {
+			byComment[row.CommentID] = map[string]int64{}
+		}
+		byComment[row.CommentID][row.Type] = row.Count
+	}
+
+	viewerReactions := map[uint64][]string{}
+	if viewer != "" {
+		var rows []model.CommentReaction
+		err := r.db.Where("comment_id IN ? AND LOWER(wallet_address) = LOWER(?)", ids, viewer).
+			Order("id ASC").
+			Find(&rows).Error
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			viewerReactions[row.CommentID] = append(viewerReactions[row.CommentID], row.Type)
+		}
+	}
+
+	for _, c := range comments {
+		c.Reactions = byComment[c.ID]
+		if c.Reactions == nil {
+			c.Reactions = map[string]int64{}
+		}
+		c.Score = 0
+		for reactionType, count := range c.Reactions {
+			c.Score += model.CommentReactionWeights[reactionType] * count
+		}
+		c.ViewerReactions = viewerReactions[c.ID]
+	}
+	return nil
+}
+
+// commentScoreSQL adalah subquery skor reaksi berbobot untuk baris comments.id,
+// disusun dari model.CommentReactionWeights agar bobot hanya didefinisikan di satu tempat
+func commentScoreSQL() string {
+	types := make([]string, 0, len(model.CommentReactionWeights))
+	for t := range model.CommentReactionWeights {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	var cases strings.Builder
+	for _, t := range types {
+		fmt.Fprintf(&cases, " WHEN '%s' THEN %d", t, model.CommentReactionWeights[t])
+	}
+	return fmt.Sprintf(
+		"(SELECT COALESCE(SUM(CASE cr.type%s ELSE 0 END), 0) FROM comment_reactions cr WHERE cr.comment_id = comments.id)",
+		cases.String())
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
 	"gorm.io/gorm"
@@ -17,11 +19,39 @@ func NewCommentRepository(db *gorm.DB) *CommentRepository {
 	return &CommentRepository{db: db}
 }
 
-// GetByProjectID mengambil semua komentar untuk sebuah proyek
-func (r *CommentRepository) GetByProjectID(projectID uint64) ([]model.Comment, error) {
+// WithContext mengembalikan repository yang menjalankan query dengan ctx, sehingga
+// pembatalan request dan request ID ikut sampai ke query dan log SQL
+func (r *CommentRepository) WithContext(ctx context.Context) CommentStore {
+	return &CommentRepository{db: r.db.WithContext(ctx)}
+}
+
+// GetByProjectID mengambil semua komentar untuk sebuah proyek beserta agregat reaksinya
+func (r *CommentRepository) GetByProjectID(projectID uint64, params CommentListParams) ([]model.Comment, error) {
+	query := r.db.Where("project_id = ?", projectID)
+	if !params.IncludeHidden {
+		// Sama seperti GetTree: menyembunyikan komentar ikut menyembunyikan seluruh balasannya
+		query = query.Where(`id NOT IN (
+			WITH RECURSIVE hidden AS (
+				SELECT id FROM comments WHERE project_id = ? AND hidden_at IS NOT NULL
+				UNION ALL
+				SELECT c.id FROM comments c JOIN hidden h ON c.parent_comment_id = h.id
+			)
+			SELECT id FROM hidden)`, projectID)
+	}
+	if params.Sort == CommentSortTop {
+		query = query.Order(commentScoreSQL() + " DESC")
+	}
+
 	var comments []model.Comment
-	result := r.db.Where("project_id = ?", projectID).Order("created_at DESC").Find(&comments)
-	return comments, result.Error
+	if err := query.Order("created_at DESC").Order("id DESC").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*model.Comment, len(comments))
+	for i := range comments {
+		ptrs[i] = &comments[i]
+	}
+	return comments, r.AttachReactions(ptrs, params.Viewer)
 }
 
 // GetByID mengambil komentar berdasarkan ID
@@ -36,17 +66,84 @@ func (r *CommentRepository) GetByID(id uint64) (*model.Comment, error) {
 
 // Create membuat komentar baru
 func (r *CommentRepository) Create(comment *model.Comment) error {
-	return r.db.Create(comment).Error
+	return translateError(r.db.Create(comment).Error)
 }
 
 // Update memperbarui komentar
 func (r *CommentRepository) Update(comment *model.Comment) error {
-	return r.db.Save(comment).Error
+	return translateError(r.db.Save(comment).Error)
+}
+
+// Edit mengganti konten dan signature komentar. Konten lama disimpan ke comment_revisions
+// dalam transaksi yang sama, dan edited_at diisi.
+func (r *CommentRepository) Edit(comment *model.Comment, content, signature string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		revision := model.CommentRevision{
+			CommentID: comment.ID,
+			Content:   comment.Content,
+			Signature: comment.Signature,
+			EditedAt:  now,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(comment).
+			Where("deleted_at IS NULL").
+			Updates(map[string]interface{}{
+				"content":   content,
+				"signature": signature,
+				"edited_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCommentDeleted
+		}
+
+		comment.Content = content
+		comment.Signature = signature
+		comment.EditedAt = &now
+		return nil
+	})
+	return translateError(err)
+}
+
+// Delete menghapus komentar. Komentar yang masih punya balasan tidak dihapus, melainkan
+// diganti placeholder "[deleted]" (konten, signature dan riwayat revisi dibuang) agar thread
+// tetap utuh. Mengembalikan true jika komentar menjadi placeholder.
+func (r *CommentRepository) Delete(id uint64) (bool, error) {
+	placeholder := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var replies int64
+		if err := tx.Model(&model.Comment{}).Where("parent_comment_id = ?", id).Count(&replies).Error; err != nil {
+			return err
+		}
+
+		if replies == 0 {
+			return tx.Delete(&model.Comment{}, "id = ?", id).Error
+		}
+
+		placeholder = true
+		if err := tx.Where("comment_id = ?", id).Delete(&model.CommentRevision{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Comment{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"content":    model.DeletedCommentContent,
+			"signature":  "",
+			"deleted_at": time.Now(),
+		}).Error
+	})
+	return placeholder, err
 }
 
-// Delete menghapus komentar
-func (r *CommentRepository) Delete(id uint64) error {
-	return r.db.Delete(&model.Comment{}, "id = ?", id).Error
+// GetRevisions mengambil riwayat revisi komentar, terlama lebih dulu
+func (r *CommentRepository) GetRevisions(commentID uint64) ([]model.CommentRevision, error) {
+	var revisions []model.CommentRevision
+	result := r.db.Where("comment_id = ?", commentID).Order("id ASC").Find(&revisions)
+	return revisions, result.Error
 }
 
 // GetReplies mengambil semua balasan untuk sebuah komentar
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+)
+
+// Batas untuk pohon komentar
+const (
+	// MaxCommentDepth adalah jumlah level komentar maksimum; komentar top-level ada di depth 0
+	MaxCommentDepth = 5
+
+	DefaultCommentPageSize = 20
+	MaxCommentPageSize     = 100
+	DefaultRepliesPerLevel = 3
+	MaxRepliesPerLevel     = 50
+)
+
+// CommentTreeParams berisi opsi untuk mengambil komentar dalam bentuk pohon
+type CommentTreeParams struct {
+	ProjectID       uint64
+	Limit           int    // jumlah komentar teratas (top-level, atau balasan jika Cursor menunjuk ke parent)
+	RepliesPerLevel int    // jumlah balasan yang disertakan per komentar di setiap level
+	MaxDepth        int    // depth absolut maksimum yang disertakan (<= MaxCommentDepth)
+	Cursor          string // next_cursor atau replies_cursor dari response sebelumnya
+	Viewer          string // wallet yang sedang login, untuk mengisi ViewerReactions
+	IncludeHidden   bool   // sertakan komentar yang disembunyikan moderator; jika false seluruh thread-nya ikut disembunyikan
+}
+
+// CommentTreePage adalah satu halaman pohon komentar
+type CommentTreePage struct {
+	Comments   []model.CommentNode
+	NextCursor string
+}
+
+// CommentCursor menunjuk posisi di antara anak-anak sebuah parent (nil = top-level).
+// After kosong berarti mulai dari balasan pertama.
+type CommentCursor struct {
+	Parent *uint64             `json:"p,omitempty"`
+	Depth  int                 `json:"d"`
+	After  *CommentCursorPoint `json:"a,omitempty"`
+}
+
+// CommentCursorPoint adalah posisi (created_at, id) komentar terakhir yang sudah dikirim
+type CommentCursorPoint struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint64    `json:"id"`
+}
+
+// CommentTreeRow adalah satu baris hasil query rekursif: komentar beserta depth dan jumlah balasannya
+type CommentTreeRow struct {
+	ID                  uint64
+	ProjectID           uint64
+	AuthorWalletAddress string
+	ParentCommentID     *uint64
+	Content             string
+	Signature           string
+	EditedAt            *time.Time
+	DeletedAt           *time.Time
+	HiddenAt            *time.Time
+	HiddenBy            *string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Depth               int
+	ReplyCount          int64
+}
+
+// GetTree mengambil komentar project beserta balasannya secara bertingkat dalam satu query
+// rekursif. Komentar top-level diurutkan terbaru lebih dulu, balasan diurutkan terlama lebih dulu.
+// Setiap node yang balasannya tidak ditampilkan semua mendapat RepliesCursor.
+func (r *CommentRepository) GetTree(params CommentTreeParams) (*CommentTreePage, error) {
+	params, cursor, err := PrepareCommentTree(params)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Depth >= params.MaxDepth {
+		return &CommentTreePage{Comments: []model.CommentNode{}}, nil
+	}
+
+	// Top-level terbaru lebih dulu, balasan terlama lebih dulu (sama dengan GetReplies)
+	parentCond, direction, comparator := "c.parent_comment_id IS NULL", "DESC", "<"
+	if cursor.Parent != nil {
+		parentCond, direction, comparator = "c.parent_comment_id = @parent", "ASC", ">"
+	}
+	afterCond := "TRUE"
+	if cursor.After != nil {
+		afterCond = fmt.Sprintf("(c.created_at, c.id) %s (@after_at, @after_id)", comparator)
+	}
+
+	named := map[string]interface{}{
+		"project":   params.ProjectID,
+		"limit":     params.Limit + 1,
+		"replies":   params.RepliesPerLevel,
+		"depth":     cursor.Depth,
+		"max_depth": params.MaxDepth,
+		"hidden":    params.IncludeHidden,
+	}
+	if cursor.Parent != nil {
+		named["parent"] = *cursor.Parent
+	}
+	if cursor.After != nil {
+		named["after_at"] = cursor.After.CreatedAt
+		named["after_id"] = cursor.After.ID
+	}
+
+	treeSQL := fmt.Sprintf(`
+		WITH RECURSIVE ranked AS (
+			SELECT c.id, c.parent_comment_id,
+				ROW_NUMBER() OVER (PARTITION BY c.parent_comment_id ORDER BY c.created_at ASC, c.id ASC) AS rn
+			FROM comments c
+			WHERE c.project_id = @project AND c.parent_comment_id IS NOT NULL AND (@hidden OR c.hidden_at IS NULL)
+		),
+		roots AS (
+			SELECT c.id
+			FROM comments c
+			WHERE c.project_id = @project AND %[1]s AND %[2]s AND (@hidden OR c.hidden_at IS NULL)
+			ORDER BY c.created_at %[3]s, c.id %[3]s
+			LIMIT @limit
+		),
+		tree AS (
+			SELECT roots.id, CAST(@depth AS integer) AS depth FROM roots
+			UNION ALL
+			SELECT k.id, tree.depth + 1
+			FROM ranked k
+			JOIN tree ON k.parent_comment_id = tree.id
+			WHERE k.rn <= @replies AND tree.depth + 1 < @max_depth
+		)
+		SELECT c.id, c.project_id, c.author_wallet_address, c.parent_comment_id, c.content, c.signature,
+			c.edited_at, c.deleted_at, c.hidden_at, c.hidden_by, c.created_at, c.updated_at, tree.depth,
+			(SELECT COUNT(*) FROM comments ch WHERE ch.parent_comment_id = c.id AND (@hidden OR ch.hidden_at IS NULL)) AS reply_count
+		FROM tree
+		JOIN comments c ON c.id = tree.id`, parentCond, afterCond, direction)
+
+	var rows []CommentTreeRow
+	if err := r.db.Raw(treeSQL, named).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	page, err := BuildCommentTree(rows, cursor, params.Limit, params.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+	return page, r.AttachReactions(page.Flatten(), params.Viewer)
+}
+
+// Flatten mengembalikan pointer ke setiap komentar di halaman, termasuk semua balasannya
+func (p *CommentTreePage) Flatten() []*model.Comment {
+	var comments []*model.Comment
+	var collect func(nodes []model.CommentNode)
+	collect = func(nodes []model.CommentNode) {
+		for i := range nodes {
+			comments = append(comments, &nodes[i].Comment)
+			collect(nodes[i].Replies)
+		}
+	}
+	collect(p.Comments)
+	return comments
+}
+
+// PrepareCommentTree menerapkan nilai default dan batas pada params serta mendekode cursor-nya
+func PrepareCommentTree(params CommentTreeParams) (CommentTreeParams, CommentCursor, error) {
+	if params.Limit <= 0 || params.Limit > MaxCommentPageSize {
+		params.Limit = DefaultCommentPageSize
+	}
+	if params.RepliesPerLevel <= 0 || params.RepliesPerLevel > MaxRepliesPerLevel {
+		params.RepliesPerLevel = DefaultRepliesPerLevel
+	}
+	if params.MaxDepth <= 0 || params.MaxDepth > MaxCommentDepth {
+		params.MaxDepth = MaxCommentDepth
+	}
+
+	cursor := CommentCursor{}
+	if params.Cursor != "" {
+		decoded, err := decodeCommentCursor(params.Cursor)
+		if err != nil {
+			return params, cursor, err
+		}
+		cursor = *decoded
+	}
+	return params, cursor, nil
+}
+
+// BuildCommentTree menyusun baris hasil query menjadi pohon dan menghitung cursor lanjutan.
+// maxDepth adalah depth maksimum yang diminta client, sehingga replies_cursor tidak pernah
+// menunjuk ke balasan di luar batas tersebut.
+func BuildCommentTree(rows []CommentTreeRow, cursor CommentCursor, limit, maxDepth int) (*CommentTreePage, error) {
+	children := make(map[uint64][]CommentTreeRow)
+	var roots []CommentTreeRow
+	for _, row := range rows {
+		if row.Depth == cursor.Depth {
+			roots = append(roots, row)
+		} else if row.ParentCommentID != nil {
+			children[*row.ParentCommentID] = append(children[*row.ParentCommentID], row)
+		}
+	}
+
+	desc := cursor.Parent == nil
+	sort.Slice(roots, func(i, j int) bool {
+		return CommentBefore(roots[i], roots[j], desc)
+	})
+
+	page := &CommentTreePage{}
+	if len(roots) > limit {
+		roots = roots[:limit]
+		last := roots[len(roots)-1]
+		next, err := encodeCommentCursor(CommentCursor{
+			Parent: cursor.Parent,
+			Depth:  cursor.Depth,
+			After:  &CommentCursorPoint{CreatedAt: last.CreatedAt, ID: last.ID},
+		})
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = next
+	}
+
+	var build func(row CommentTreeRow) (model.CommentNode, error)
+	build = func(row CommentTreeRow) (model.CommentNode, error) {
+		node := model.CommentNode{
+			Comment: model.Comment{
+				ID:                  row.ID,
+				ProjectID:           row.ProjectID,
+				AuthorWalletAddress: row.AuthorWalletAddress,
+				ParentCommentID:     row.ParentCommentID,
+				Content:             row.Content,
+				Signature:           row.Signature,
+				EditedAt:            row.EditedAt,
+				DeletedAt:           row.DeletedAt,
+				HiddenAt:            row.HiddenAt,
+				HiddenBy:            row.HiddenBy,
+				CreatedAt:           row.CreatedAt,
+				UpdatedAt:           row.UpdatedAt,
+			},
+			Depth:      row.Depth,
+			ReplyCount: row.ReplyCount,
+			Replies:    []model.CommentNode{},
+		}
+
+		replies := children[row.ID]
+		sort.Slice(replies, func(i, j int) bool {
+			return CommentBefore(replies[i], replies[j], false)
+		})
+		for _, reply := range replies {
+			child, err := build(reply)
+			if err != nil {
+				return node, err
+			}
+			node.Replies = append(node.Replies, child)
+		}
+
+		// Balasan yang belum ditampilkan bisa dimuat lewat replies_cursor,
+		// kecuali node sudah berada di depth maksimum yang diminta
+		if int64(len(replies)) < row.ReplyCount && row.Depth+1 < maxDepth {
+			next := CommentCursor{Parent: &node.ID, Depth: row.Depth + 1}
+			if len(replies) > 0 {
+				last := replies[len(replies)-1]
+				next.After = &CommentCursorPoint{CreatedAt: last.CreatedAt, ID: last.ID}
+			}
+			encoded, err := encodeCommentCursor(next)
+			if err != nil {
+				return node, err
+			}
+			node.RepliesCursor = encoded
+		}
+		return node, nil
+	}
+
+	page.Comments = make([]model.CommentNode, 0, len(roots))
+	for _, root := range roots {
+		node, err := build(root)
+		if err != nil {
+			return nil, err
+		}
+		page.Comments = append(page.Comments, node)
+	}
+	return page, nil
+}
+
+// Depth mengembalikan depth komentar (0 untuk komentar top-level) dengan menelusuri parent-nya
+func (r *CommentRepository) Depth(id uint64) (int, error) {
+	var depth *int
+	err := r.db.Raw(`
+		WITH RECURSIVE chain AS (
+			SELECT id, parent_comment_id, 0 AS depth FROM comments WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.parent_comment_id, chain.depth + 1
+			FROM comments c
+			JOIN chain ON c.id = chain.parent_comment_id
+			WHERE chain.depth < ?
+		)
+		SELECT MAX(depth) FROM chain`, id, MaxCommentDepth).Scan(&depth).Error
+	if err != nil || depth == nil {
+		return 0, err
+	}
+	return *depth, nil
+}
+
+// CommentBefore mengurutkan berdasarkan (created_at, id); baris yang sama tidak pernah "sebelum" dirinya sendiri
+func CommentBefore(a, b CommentTreeRow, desc bool) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt) == desc
+	}
+	if desc {
+		return a.ID > b.ID
+	}
+	return a.ID < b.ID
+}
+
+func encodeCommentCursor(cursor CommentCursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+func decodeCommentCursor(encoded string) (*CommentCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor CommentCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Depth < 0 || cursor.Depth >= MaxCommentDepth || (cursor.Parent == nil) != (cursor.Depth == 0) {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
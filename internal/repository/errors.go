@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/lib/pq"
+)
+
+// SQLSTATE Postgres yang diterjemahkan ke error domain
+const (
+	pgNotNullViolation    = "23502"
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
+	pgStringTooLong       = "22001"
+	pgInvalidTextValue    = "22P02"
+)
+
+// Error domain yang dikembalikan repository, baik Postgres maupun in-memory. Pesannya sudah
+// ditujukan ke client sehingga handler cukup meneruskannya ke ErrorHandler.
+var (
+	ErrProjectNotFound   = apperror.NotFound("Project not found")
+	ErrProjectNotDeleted = apperror.Conflict("Project is not deleted")
+	ErrInvestorExists    = apperror.Conflict("Investor already exists in this project")
+	ErrCoOwnerExists     = apperror.Conflict("Wallet is already a co-owner of this project")
+	ErrCoOwnerNotFound   = apperror.NotFound("Co-owner not found")
+	ErrCommentDeleted    = apperror.Conflict("Deleted comments cannot be edited")
+	ErrAlreadyReported   = apperror.Conflict("You have already reported this comment")
+)
+
+// constraintErrors berisi pesan untuk client (dan field yang bermasalah, jika ada) bagi
+// constraint yang bisa dilanggar oleh input client. Constraint lain memakai pesan generik.
+var constraintErrors = map[string]struct{ message, field string }{
+	"uni_user_profiles_username":    {"Username already exists", "username"},
+	"uni_user_profiles_email":       {"Email already exists", "email"},
+	"uidx_investments_chain_tx_log": {"Investment for this transaction log is already recorded", ""},
+	"fk_investments_project":        {"Project not found", ""},
+	"fk_comments_project":           {"Project not found", ""},
+	"fk_comments_parent_comment":    {"Parent comment not found", "parent_comment_id"},
+	"fk_projects_links":             {"Project not found", ""},
+	"fk_project_co_owners_project":  {"Project not found", ""},
+}
+
+// ConstraintError membuat error domain berjenis kind untuk pelanggaran constraint. Dipakai
+// translateError dan store in-memory agar keduanya menghasilkan pesan yang sama.
+func ConstraintError(kind error, constraint string) *apperror.Error {
+	if known, ok := constraintErrors[constraint]; ok {
+		e := &apperror.Error{Kind: kind, Message: known.message}
+		if known.field != "" {
+			e.WithField(known.field, known.message)
+		}
+		return e
+	}
+	if kind == apperror.ErrConflict {
+		return apperror.Conflict(fmt.Sprintf("duplicate value violates %s", constraint))
+	}
+	return apperror.NotFound(fmt.Sprintf("referenced row not found (%s)", constraint))
+}
+
+// translateError menerjemahkan error driver Postgres (pgx maupun lib/pq) menjadi error
+// apperror. Error lain, termasuk nil, dikembalikan apa adanya.
+func translateError(err error) error {
+	if err == nil || apperror.As(err) != nil {
+		return err
+	}
+
+	var code, constraint, message string
+	var pgErr *pgconn.PgError
+	var pqErr *pq.Error
+	switch {
+	case errors.As(err, &pgErr):
+		code, constraint, message = pgErr.Code, pgErr.ConstraintName, pgErr.Message
+	case errors.As(err, &pqErr):
+		code, constraint, message = string(pqErr.Code), pqErr.Constraint, pqErr.Message
+	default:
+		return err
+	}
+
+	switch code {
+	case pgUniqueViolation:
+		return ConstraintError(apperror.ErrConflict, constraint).Wrap(err)
+	case pgForeignKeyViolation:
+		// Insert/update ke baris yang tidak ada berarti referensinya tidak ditemukan;
+		// delete/update baris yang masih direferensikan adalah konflik
+		if strings.HasPrefix(message, "insert or update") {
+			return ConstraintError(apperror.ErrNotFound, constraint).Wrap(err)
+		}
+		return apperror.Conflict(fmt.Sprintf("row is still referenced (%s)", constraint)).Wrap(err)
+	case pgNotNullViolation, pgCheckViolation, pgStringTooLong, pgInvalidTextValue:
+		return apperror.Validation(message).Wrap(err)
+	}
+	return err
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 
 	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
@@ -17,13 +18,34 @@ func NewExternalLinkRepository(db *gorm.DB) *ExternalLinkRepository {
 	return &ExternalLinkRepository{db: db}
 }
 
-// GetByProjectID mengambil semua external links untuk sebuah proyek
+// WithContext mengembalikan repository yang menjalankan query dengan ctx, sehingga
+// pembatalan request dan request ID ikut sampai ke query dan log SQL
+func (r *ExternalLinkRepository) WithContext(ctx context.Context) ExternalLinkStore {
+	return &ExternalLinkRepository{db: r.db.WithContext(ctx)}
+}
+
+// orderLinks mengurutkan external links berdasarkan position; dipakai juga saat preload Project.Links
+func orderLinks(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, id ASC")
+}
+
+// GetByProjectID mengambil semua external links untuk sebuah proyek, urut berdasarkan position
 func (r *ExternalLinkRepository) GetByProjectID(projectID uint64) ([]model.ExternalLink, error) {
 	var links []model.ExternalLink
-	result := r.db.Where("project_id = ?", projectID).Order("created_at ASC").Find(&links)
+	result := r.db.Where("project_id = ?", projectID).Scopes(orderLinks).Find(&links)
 	return links, result.Error
 }
 
+// NextPosition mengembalikan position untuk link yang ditambahkan di akhir daftar
+func (r *ExternalLinkRepository) NextPosition(projectID uint64) (int, error) {
+	var next int
+	err := r.db.Model(&model.ExternalLink{}).
+		Select("COALESCE(MAX(position) + 1, 0)").
+		Where("project_id = ?", projectID).
+		Scan(&next).Error
+	return next, err
+}
+
 // GetByID mengambil external link berdasarkan ID
 func (r *ExternalLinkRepository) GetByID(id uint64) (*model.ExternalLink, error) {
 	var link model.ExternalLink
@@ -34,22 +56,47 @@ func (r *ExternalLinkRepository) GetByID(id uint64) (*model.ExternalLink, error)
 	return &link, result.Error
 }
 
-// Create membuat external link baru
+// Create membuat external link baru dan menaikkan versi project-nya
 func (r *ExternalLinkRepository) Create(link *model.ExternalLink) error {
-	return r.db.Create(link).Error
+	return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(link).Error; err != nil {
+			return err
+		}
+		return touchProject(tx, link.ProjectID)
+	}))
 }
 
-// Update memperbarui external link
+// Update memperbarui external link dan menaikkan versi project-nya
 func (r *ExternalLinkRepository) Update(link *model.ExternalLink) error {
-	return r.db.Save(link).Error
+	return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(link).Error; err != nil {
+			return err
+		}
+		return touchProject(tx, link.ProjectID)
+	}))
 }
 
-// Delete menghapus external link
+// Delete menghapus external link dan menaikkan versi project-nya
 func (r *ExternalLinkRepository) Delete(id uint64) error {
-	return r.db.Delete(&model.ExternalLink{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var link model.ExternalLink
+		result := tx.Where("id = ?", id).Limit(1).Find(&link)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Delete(&link).Error; err != nil {
+			return err
+		}
+		return touchProject(tx, link.ProjectID)
+	})
 }
 
-// DeleteByProjectID menghapus semua external links untuk sebuah proyek
+// DeleteByProjectID menghapus semua external links untuk sebuah proyek dan menaikkan versinya
 func (r *ExternalLinkRepository) DeleteByProjectID(projectID uint64) error {
-	return r.db.Where("project_id = ?", projectID).Delete(&model.ExternalLink{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&model.ExternalLink{}).Error; err != nil {
+			return err
+		}
+		return touchProject(tx, projectID)
+	})
 }
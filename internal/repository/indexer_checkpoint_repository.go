@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IndexerCheckpointRepository menangani operasi database untuk checkpoint indexer on-chain
+type IndexerCheckpointRepository struct {
+	db *gorm.DB
+}
+
+// NewIndexerCheckpointRepository membuat instance baru dari IndexerCheckpointRepository
+func NewIndexerCheckpointRepository(db *gorm.DB) *IndexerCheckpointRepository {
+	return &IndexerCheckpointRepository{db: db}
+}
+
+// GetByName mengambil checkpoint berdasarkan nama indexer
+func (r *IndexerCheckpointRepository) GetByName(name string) (*model.IndexerCheckpoint, error) {
+	var checkpoint model.IndexerCheckpoint
+	result := r.db.First(&checkpoint, "name = ?", name)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &checkpoint, result.Error
+}
+
+// SaveBatch menyimpan entri ledger hasil indexing, event untuk project yang belum ada, dan
+// memajukan checkpoint dalam satu transaksi. Entri yang sudah ada (chain_id, tx_hash, log_index
+// sama) diabaikan sehingga rentang blok aman diproses ulang. Mengembalikan jumlah entri ledger
+// yang benar-benar tercatat.
+func (r *IndexerCheckpointRepository) SaveBatch(checkpoint *model.IndexerCheckpoint, investments []model.Investment, pending []model.IndexerPendingEvent) (int64, error) {
+	var inserted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(investments) > 0 {
+			result := tx.Clauses(clause.OnConflict{
+				Columns:     []clause.Column{{Name: "chain_id"}, {Name: "tx_hash"}, {Name: "log_index"}},
+				TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "tx_hash IS NOT NULL"}}},
+				DoNothing:   true,
+			}).Create(&investments)
+			if result.Error != nil {
+				return result.Error
+			}
+			inserted = result.RowsAffected
+		}
+
+		if len(pending) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&pending).Error; err != nil {
+				return err
+			}
+		}
+
+		checkpoint.UpdatedAt = time.Now()
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"chain_id", "last_block", "updated_at"}),
+		}).Create(checkpoint).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return inserted, nil
+}
+
+// PromotePending memindahkan event tertunda milik chainID yang project-nya sekarang ada (termasuk
+// yang di-soft delete) ke ledger investasi, dan mengembalikan jumlah entri ledger yang tercatat.
+func (r *IndexerCheckpointRepository) PromotePending(chainID int64) (int64, error) {
+	result := r.db.Exec(`
+		WITH moved AS (
+			DELETE FROM indexer_pending_events e
+			USING projects p
+			WHERE e.chain_id = ? AND p.id = e.project_id
+			RETURNING e.*
+		)
+		INSERT INTO investments (project_id, wallet_address, kind, source, amount, token_address,
+			chain_id, tx_hash, log_index, block_number, invested_at, created_at)
+		SELECT project_id, wallet_address, kind, ?, amount, token_address,
+			chain_id, tx_hash, log_index, block_number, invested_at, ?
+		FROM moved
+		ON CONFLICT (chain_id, tx_hash, log_index) WHERE tx_hash IS NOT NULL DO NOTHING`,
+		chainID, model.InvestmentSourceIndexed, time.Now())
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"gorm.io/gorm"
+)
+
+// InvestmentRepository menangani operasi database untuk ledger investasi
+type InvestmentRepository struct {
+	db *gorm.DB
+}
+
+// NewInvestmentRepository membuat instance baru dari InvestmentRepository
+func NewInvestmentRepository(db *gorm.DB) *InvestmentRepository {
+	return &InvestmentRepository{db: db}
+}
+
+// WithContext mengembalikan repository yang menjalankan query dengan ctx, sehingga
+// pembatalan request dan request ID ikut sampai ke query dan log SQL
+func (r *InvestmentRepository) WithContext(ctx context.Context) InvestmentStore {
+	return &InvestmentRepository{db: r.db.WithContext(ctx)}
+}
+
+// InvestmentListParams berisi filter dan pagination untuk listing investasi
+type InvestmentListParams struct {
+	WalletAddress string
+	Limit         int
+	Offset        int
+}
+
+// Create mencatat investasi baru
+func (r *InvestmentRepository) Create(investment *model.Investment) error {
+	return translateError(r.db.Create(investment).Error)
+}
+
+// ListByProject mengambil investasi sebuah project, terbaru lebih dulu
+func (r *InvestmentRepository) ListByProject(projectID uint64, params InvestmentListParams) ([]model.Investment, int64, error) {
+	query := r.db.Model(&model.Investment{}).Where("project_id = ?", projectID)
+	if params.WalletAddress != "" {
+		query = query.Where("LOWER(wallet_address) = LOWER(?)", params.WalletAddress)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var investments []model.Investment
+	result := query.
+		Order("invested_at DESC, id DESC").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		Find(&investments)
+	return investments, total, result.Error
+}
+
+// Summary menghitung agregat ledger investasi sebuah project.
+// Total nominal adalah nilai bersih (investasi dikurangi refund).
+func (r *InvestmentRepository) Summary(projectID uint64) (*model.InvestmentSummary, error) {
+	invest, refund := model.InvestmentKindInvestment, model.InvestmentKindRefund
+
+	var stats struct {
+		InvestorCount   int64
+		InvestmentCount int64
+		RefundCount     int64
+		FirstInvestedAt *time.Time
+		LastInvestedAt  *time.Time
+	}
+	err := r.db.Model(&model.Investment{}).
+		Select(`COUNT(DISTINCT LOWER(wallet_address)) FILTER (WHERE kind = ?) AS investor_count,
+			COUNT(*) FILTER (WHERE kind = ?) AS investment_count,
+			COUNT(*) FILTER (WHERE kind = ?) AS refund_count,
+			MIN(invested_at) FILTER (WHERE kind = ?) AS first_invested_at,
+			MAX(invested_at) FILTER (WHERE kind = ?) AS last_invested_at`, invest, invest, refund, invest, invest).
+		Where("project_id = ?", projectID).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := []model.InvestmentTotal{}
+	err = r.db.Model(&model.Investment{}).
+		Select("chain_id, token_address, SUM(CASE WHEN kind = ? THEN -amount ELSE amount END)::text AS amount", refund).
+		Where("project_id = ?", projectID).
+		Group("chain_id, token_address").
+		Order("chain_id, token_address").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.InvestmentSummary{
+		ProjectID:       projectID,
+		InvestorCount:   stats.InvestorCount,
+		InvestmentCount: stats.InvestmentCount,
+		RefundCount:     stats.RefundCount,
+		Totals:          totals,
+		FirstInvestedAt: stats.FirstInvestedAt,
+		LastInvestedAt:  stats.LastInvestedAt,
+	}, nil
+}
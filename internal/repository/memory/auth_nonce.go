@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// AuthNonceRepository adalah implementasi in-memory dari repository.AuthNonceStore
+type AuthNonceRepository struct {
+	db *DB
+}
+
+// NewAuthNonceRepository membuat instance baru dari AuthNonceRepository
+func NewAuthNonceRepository(db *DB) *AuthNonceRepository {
+	return &AuthNonceRepository{db: db}
+}
+
+// WithContext mengembalikan repository yang sama; store in-memory tidak memakai context
+func (r *AuthNonceRepository) WithContext(ctx context.Context) repository.AuthNonceStore {
+	return r
+}
+
+var _ repository.AuthNonceStore = (*AuthNonceRepository)(nil)
+
+// Create menyimpan nonce baru
+func (r *AuthNonceRepository) Create(nonce *model.AuthNonce) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.db.nonces[nonce.Nonce] != nil {
+		return errDuplicate("auth_nonces_pkey")
+	}
+	if nonce.CreatedAt.IsZero() {
+		nonce.CreatedAt = time.Now()
+	}
+	stored := detach(*nonce)
+	r.db.nonces[stored.Nonce] = &stored
+	return nil
+}
+
+// Consume menandai nonce sebagai terpakai secara atomik.
+// Mengembalikan false jika nonce tidak ada, sudah dipakai, atau kadaluarsa.
+func (r *AuthNonceRepository) Consume(nonce string) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := time.Now()
+	stored := r.db.nonces[nonce]
+	if stored == nil || stored.UsedAt != nil || !stored.ExpiresAt.After(now) {
+		return false, nil
+	}
+	stored.UsedAt = &now
+	return true, nil
+}
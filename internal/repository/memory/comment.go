@@ -0,0 +1,390 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// CommentRepository adalah implementasi in-memory dari repository.CommentStore
+type CommentRepository struct {
+	db *DB
+}
+
+// NewCommentRepository membuat instance baru dari CommentRepository
+func NewCommentRepository(db *DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// WithContext mengembalikan repository yang sama; store in-memory tidak memakai context
+func (r *CommentRepository) WithContext(ctx context.Context) repository.CommentStore {
+	return r
+}
+
+var _ repository.CommentStore = (*CommentRepository)(nil)
+
+// GetByProjectID mengambil semua komentar untuk sebuah proyek beserta agregat reaksinya
+func (r *CommentRepository) GetByProjectID(projectID uint64, params repository.CommentListParams) ([]model.Comment, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	comments := []model.Comment{}
+	for _, c := range r.db.comments {
+		if c.ProjectID == projectID && (params.IncludeHidden || !r.db.inHiddenThread(c)) {
+			comments = append(comments, *c)
+		}
+	}
+
+	ptrs := make([]*model.Comment, len(comments))
+	for i := range comments {
+		ptrs[i] = &comments[i]
+	}
+	r.db.attachReactions(ptrs, params.Viewer)
+
+	sort.Slice(comments, func(i, j int) bool {
+		a, b := comments[i], comments[j]
+		if params.Sort == repository.CommentSortTop && a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+	return comments, nil
+}
+
+// GetTree mengambil komentar project beserta balasannya secara bertingkat
+func (r *CommentRepository) GetTree(params repository.CommentTreeParams) (*repository.CommentTreePage, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var comments []model.Comment
+	for _, c := range r.db.comments {
+		if c.ProjectID == params.ProjectID {
+			comments = append(comments, *c)
+		}
+	}
+
+	page, err := commentTree(comments, params)
+	if err != nil {
+		return nil, err
+	}
+	r.db.attachReactions(page.Flatten(), params.Viewer)
+	return page, nil
+}
+
+// GetByID mengambil komentar berdasarkan ID
+func (r *CommentRepository) GetByID(id uint64) (*model.Comment, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	stored := r.db.comments[id]
+	if stored == nil {
+		return nil, nil
+	}
+	comment := *stored
+	return &comment, nil
+}
+
+// GetReplies mengambil semua balasan untuk sebuah komentar
+func (r *CommentRepository) GetReplies(parentID uint64) ([]model.Comment, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	replies := []model.Comment{}
+	for _, c := range r.db.comments {
+		if c.ParentCommentID != nil && *c.ParentCommentID == parentID {
+			replies = append(replies, *c)
+		}
+	}
+	sort.Slice(replies, func(i, j int) bool {
+		if !replies[i].CreatedAt.Equal(replies[j].CreatedAt) {
+			return replies[i].CreatedAt.Before(replies[j].CreatedAt)
+		}
+		return replies[i].ID < replies[j].ID
+	})
+	return replies, nil
+}
+
+// Depth mengembalikan depth komentar (0 untuk komentar top-level) dengan menelusuri parent-nya
+func (r *CommentRepository) Depth(id uint64) (int, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	comment := r.db.comments[id]
+	if comment == nil {
+		return 0, nil
+	}
+	depth := 0
+	for comment.ParentCommentID != nil && depth < repository.MaxCommentDepth {
+		parent := r.db.comments[*comment.ParentCommentID]
+		if parent == nil {
+			break
+		}
+		comment = parent
+		depth++
+	}
+	return depth, nil
+}
+
+// Create membuat komentar baru
+func (r *CommentRepository) Create(comment *model.Comment) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if comment.ID != 0 && r.db.comments[comment.ID] != nil {
+		return errDuplicate("comments_pkey")
+	}
+	if err := r.db.checkCommentReferences(comment); err != nil {
+		return err
+	}
+
+	comment.ID = r.db.useID("comments", comment.ID)
+	now := time.Now()
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = now
+	}
+	if comment.UpdatedAt.IsZero() {
+		comment.UpdatedAt = now
+	}
+	r.db.comments[comment.ID] = storedComment(comment)
+	return nil
+}
+
+// Update memperbarui komentar (membuatnya jika belum ada, seperti Save di GORM)
+func (r *CommentRepository) Update(comment *model.Comment) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if err := r.db.checkCommentReferences(comment); err != nil {
+		return err
+	}
+	comment.ID = r.db.useID("comments", comment.ID)
+	comment.UpdatedAt = time.Now()
+	r.db.comments[comment.ID] = storedComment(comment)
+	return nil
+}
+
+// Edit mengganti konten dan signature komentar; konten lama disimpan sebagai revisi
+func (r *CommentRepository) Edit(comment *model.Comment, content, signature string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored := r.db.comments[comment.ID]
+	if stored == nil || stored.DeletedAt != nil {
+		return repository.ErrCommentDeleted
+	}
+
+	now := time.Now()
+	revisionID := r.db.nextID("comment_revisions")
+	revision := detach(model.CommentRevision{
+		ID:        revisionID,
+		CommentID: comment.ID,
+		Content:   comment.Content,
+		Signature: comment.Signature,
+		EditedAt:  now,
+		CreatedAt: now,
+	})
+	r.db.revisions[revisionID] = &revision
+
+	stored.Content = strings.Clone(content)
+	stored.Signature = strings.Clone(signature)
+	stored.EditedAt = &now
+	stored.UpdatedAt = now
+
+	comment.Content = content
+	comment.Signature = signature
+	comment.EditedAt = &now
+	return nil
+}
+
+// Delete menghapus komentar, atau menggantinya dengan placeholder jika masih punya balasan.
+// Mengembalikan true jika komentar menjadi placeholder.
+func (r *CommentRepository) Delete(id uint64) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored := r.db.comments[id]
+	if stored == nil {
+		return false, nil
+	}
+
+	for _, c := range r.db.comments {
+		if c.ParentCommentID != nil && *c.ParentCommentID == id {
+			for revisionID, revision := range r.db.revisions {
+				if revision.CommentID == id {
+					delete(r.db.revisions, revisionID)
+				}
+			}
+			now := time.Now()
+			stored.Content = model.DeletedCommentContent
+			stored.Signature = ""
+			stored.DeletedAt = &now
+			stored.UpdatedAt = now
+			return true, nil
+		}
+	}
+
+	r.db.deleteComment(id)
+	return false, nil
+}
+
+// GetRevisions mengambil riwayat revisi komentar, terlama lebih dulu
+func (r *CommentRepository) GetRevisions(commentID uint64) ([]model.CommentRevision, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	revisions := []model.CommentRevision{}
+	for _, revision := range r.db.revisions {
+		if revision.CommentID == commentID {
+			revisions = append(revisions, *revision)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].ID < revisions[j].ID })
+	return revisions, nil
+}
+
+// AddReaction menambahkan reaksi; mengembalikan true jika reaksi baru tercatat
+func (r *CommentRepository) AddReaction(reaction *model.CommentReaction) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, existing := range r.db.reactions {
+		if existing.CommentID == reaction.CommentID && existing.Type == reaction.Type &&
+			sameFold(existing.WalletAddress, reaction.WalletAddress) {
+			return false, nil
+		}
+	}
+	if r.db.comments[reaction.CommentID] == nil {
+		return false, errForeignKey("comment_reactions", "fk_comment_reactions_comment")
+	}
+
+	reaction.ID = r.db.useID("comment_reactions", reaction.ID)
+	if reaction.CreatedAt.IsZero() {
+		reaction.CreatedAt = time.Now()
+	}
+	stored := *reaction
+	stored.Comment = model.Comment{}
+	stored = detach(stored)
+	r.db.reactions[reaction.ID] = &stored
+	return true, nil
+}
+
+// RemoveReaction menghapus reaksi wallet; mengembalikan false jika reaksi tidak ada
+func (r *CommentRepository) RemoveReaction(commentID uint64, walletAddress, reactionType string) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	removed := false
+	for id, reaction := range r.db.reactions {
+		if reaction.CommentID == commentID && reaction.Type == reactionType && sameFold(reaction.WalletAddress, walletAddress) {
+			delete(r.db.reactions, id)
+			removed = true
+		}
+	}
+	return removed, nil
+}
+
+// AttachReactions mengisi Reactions, Score dan ViewerReactions untuk setiap komentar
+func (r *CommentRepository) AttachReactions(comments []*model.Comment, viewer string) error {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	r.db.attachReactions(comments, viewer)
+	return nil
+}
+
+// attachReactions adalah AttachReactions tanpa lock. Caller harus memegang lock.
+func (db *DB) attachReactions(comments []*model.Comment, viewer string) {
+	var viewerReactions []*model.CommentReaction
+	counts := map[uint64]map[string]int64{}
+	for _, reaction := range db.reactions {
+		if counts[reaction.CommentID] == nil {
+			counts[reaction.CommentID] = map[string]int64{}
+		}
+		counts[reaction.CommentID][reaction.Type]++
+		if viewer != "" && sameFold(reaction.WalletAddress, viewer) {
+			viewerReactions = append(viewerReactions, reaction)
+		}
+	}
+	sort.Slice(viewerReactions, func(i, j int) bool { return viewerReactions[i].ID < viewerReactions[j].ID })
+
+	for _, c := range comments {
+		c.Reactions = counts[c.ID]
+		if c.Reactions == nil {
+			c.Reactions = map[string]int64{}
+		}
+		c.Score = 0
+		for reactionType, count := range c.Reactions {
+			c.Score += model.CommentReactionWeights[reactionType] * count
+		}
+		c.ViewerReactions = nil
+		for _, reaction := range viewerReactions {
+			if reaction.CommentID == c.ID {
+				c.ViewerReactions = append(c.ViewerReactions, reaction.Type)
+			}
+		}
+	}
+}
+
+// checkCommentReferences meniru foreign key project_id dan parent_comment_id
+func (db *DB) checkCommentReferences(comment *model.Comment) error {
+	if db.projects[comment.ProjectID] == nil {
+		return errForeignKey("comments", "fk_comments_project")
+	}
+	if comment.ParentCommentID != nil && db.comments[*comment.ParentCommentID] == nil {
+		return errForeignKey("comments", "fk_comments_parent_comment")
+	}
+	return nil
+}
+
+// deleteComment menghapus permanen komentar beserta revisi, reaksi dan laporannya
+func (db *DB) deleteComment(id uint64) {
+	delete(db.comments, id)
+	for revisionID, revision := range db.revisions {
+		if revision.CommentID == id {
+			delete(db.revisions, revisionID)
+		}
+	}
+	for reactionID, reaction := range db.reactions {
+		if reaction.CommentID == id {
+			delete(db.reactions, reactionID)
+		}
+	}
+	for reportID, report := range db.reports {
+		if report.CommentID == id {
+			delete(db.reports, reportID)
+		}
+	}
+}
+
+// storedComment menyalin komentar tanpa relasi dan field turunan
+func storedComment(comment *model.Comment) *model.Comment {
+	stored := *comment
+	stored.Reactions = nil
+	stored.Score = 0
+	stored.ViewerReactions = nil
+	stored.Project = model.Project{}
+	stored.ParentComment = nil
+	stored = detach(stored)
+	return &stored
+}
+
+// inHiddenThread mengecek apakah komentar atau salah satu parent-nya disembunyikan moderator.
+// Caller harus memegang lock.
+func (db *DB) inHiddenThread(c *model.Comment) bool {
+	for depth := 0; c != nil && depth <= repository.MaxCommentDepth; depth++ {
+		if c.HiddenAt != nil {
+			return true
+		}
+		if c.ParentCommentID == nil {
+			return false
+		}
+		c = db.comments[*c.ParentCommentID]
+	}
+	return false
+}
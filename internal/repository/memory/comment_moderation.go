@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// Report mencatat laporan komentar. Wallet yang laporannya masih terbuka untuk komentar
+// yang sama tidak bisa melapor lagi.
+func (r *CommentRepository) Report(report *model.CommentReport) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, existing := range r.db.reports {
+		if existing.CommentID == report.CommentID && existing.ResolvedAt == nil &&
+			sameFold(existing.ReporterWalletAddress, report.ReporterWalletAddress) {
+			return repository.ErrAlreadyReported
+		}
+	}
+	if r.db.comments[report.CommentID] == nil {
+		return errForeignKey("comment_reports", "fk_comment_reports_comment")
+	}
+
+	report.ID = r.db.useID("comment_reports", report.ID)
+	if report.CreatedAt.IsZero() {
+		report.CreatedAt = time.Now()
+	}
+	stored := *report
+	stored.Comment = model.Comment{}
+	stored = detach(stored)
+	r.db.reports[report.ID] = &stored
+	return nil
+}
+
+// ModerationQueue mengambil komentar yang punya laporan terbuka, paling banyak dilaporkan lebih dulu
+func (r *CommentRepository) ModerationQueue(params repository.ModerationQueueParams) ([]model.ReportedComment, int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	byComment := map[uint64]*model.ReportedComment{}
+	for _, report := range r.db.reports {
+		if report.ResolvedAt != nil {
+			continue
+		}
+		comment := r.db.comments[report.CommentID]
+		if comment == nil || (params.ProjectID != nil && comment.ProjectID != *params.ProjectID) {
+			continue
+		}
+		entry := byComment[comment.ID]
+		if entry == nil {
+			entry = &model.ReportedComment{Comment: *storedComment(comment)}
+			byComment[comment.ID] = entry
+		}
+		entry.ReportCount++
+		if report.CreatedAt.After(entry.LastReportedAt) {
+			entry.LastReportedAt = report.CreatedAt
+		}
+		entry.Reports = append(entry.Reports, *report)
+	}
+
+	queue := make([]model.ReportedComment, 0, len(byComment))
+	for _, entry := range byComment {
+		sort.Slice(entry.Reports, func(i, j int) bool {
+			a, b := entry.Reports[i], entry.Reports[j]
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+			return a.ID < b.ID
+		})
+		queue = append(queue, *entry)
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		a, b := queue[i], queue[j]
+		if a.ReportCount != b.ReportCount {
+			return a.ReportCount > b.ReportCount
+		}
+		if !a.LastReportedAt.Equal(b.LastReportedAt) {
+			return a.LastReportedAt.After(b.LastReportedAt)
+		}
+		return a.Comment.ID > b.Comment.ID
+	})
+
+	total := int64(len(queue))
+	if params.Offset >= len(queue) {
+		return []model.ReportedComment{}, total, nil
+	}
+	queue = queue[params.Offset:]
+	if params.Limit > 0 && len(queue) > params.Limit {
+		queue = queue[:params.Limit]
+	}
+	return queue, total, nil
+}
+
+// SetHidden menyembunyikan atau menampilkan kembali komentar. Menyembunyikan komentar
+// sekaligus menyelesaikan laporan terbukanya.
+func (r *CommentRepository) SetHidden(commentID uint64, hidden bool, moderator string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	comment := r.db.comments[commentID]
+	if comment == nil {
+		return nil
+	}
+	now := time.Now()
+	comment.UpdatedAt = now
+	if !hidden {
+		comment.HiddenAt = nil
+		comment.HiddenBy = nil
+		return nil
+	}
+
+	hiddenBy := moderator
+	comment.HiddenAt = &now
+	comment.HiddenBy = &hiddenBy
+	r.db.resolveReports(commentID, model.CommentReportResolutionHidden, moderator)
+	return nil
+}
+
+// ResolveReports menyelesaikan semua laporan terbuka sebuah komentar
+func (r *CommentRepository) ResolveReports(commentID uint64, resolution, moderator string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	r.db.resolveReports(commentID, resolution, moderator)
+	return nil
+}
+
+func (db *DB) resolveReports(commentID uint64, resolution, moderator string) {
+	now := time.Now()
+	for _, report := range db.reports {
+		if report.CommentID != commentID || report.ResolvedAt != nil {
+			continue
+		}
+		res, by, at := resolution, moderator, now
+		report.Resolution = &res
+		report.ResolvedBy = &by
+		report.ResolvedAt = &at
+	}
+}
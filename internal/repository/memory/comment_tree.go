@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// commentTree menyusun halaman pohon komentar dari seluruh komentar sebuah project dengan aturan
+// yang sama seperti query GetTree di Postgres (filter hidden, urutan, batas balasan per level
+// dan cursor). Reaksi tidak diisi.
+func commentTree(comments []model.Comment, params repository.CommentTreeParams) (*repository.CommentTreePage, error) {
+	params, cursor, err := repository.PrepareCommentTree(params)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Depth >= params.MaxDepth {
+		return &repository.CommentTreePage{Comments: []model.CommentNode{}}, nil
+	}
+
+	var roots []repository.CommentTreeRow
+	children := make(map[uint64][]repository.CommentTreeRow)
+	for _, c := range comments {
+		if c.ProjectID != params.ProjectID || (!params.IncludeHidden && c.HiddenAt != nil) {
+			continue
+		}
+		row := commentTreeRowOf(c)
+		if c.ParentCommentID == nil {
+			roots = append(roots, row)
+		} else {
+			children[*c.ParentCommentID] = append(children[*c.ParentCommentID], row)
+		}
+	}
+	for parent := range children {
+		replies := children[parent]
+		sort.Slice(replies, func(i, j int) bool {
+			return repository.CommentBefore(replies[i], replies[j], false)
+		})
+	}
+
+	desc := cursor.Parent == nil
+	if !desc {
+		roots = children[*cursor.Parent]
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		return repository.CommentBefore(roots[i], roots[j], desc)
+	})
+
+	var rows []repository.CommentTreeRow
+	var walk func(row repository.CommentTreeRow, depth int)
+	walk = func(row repository.CommentTreeRow, depth int) {
+		row.Depth = depth
+		row.ReplyCount = int64(len(children[row.ID]))
+		rows = append(rows, row)
+		if depth+1 >= params.MaxDepth {
+			return
+		}
+		for i, reply := range children[row.ID] {
+			if i >= params.RepliesPerLevel {
+				break
+			}
+			walk(reply, depth+1)
+		}
+	}
+
+	taken := 0
+	for _, root := range roots {
+		if cursor.After != nil {
+			after := repository.CommentTreeRow{ID: cursor.After.ID, CreatedAt: cursor.After.CreatedAt}
+			if !repository.CommentBefore(after, root, desc) {
+				continue
+			}
+		}
+		if taken == params.Limit+1 {
+			break
+		}
+		walk(root, cursor.Depth)
+		taken++
+	}
+
+	return repository.BuildCommentTree(rows, cursor, params.Limit, params.MaxDepth)
+}
+
+func commentTreeRowOf(c model.Comment) repository.CommentTreeRow {
+	return repository.CommentTreeRow{
+		ID:                  c.ID,
+		ProjectID:           c.ProjectID,
+		AuthorWalletAddress: c.AuthorWalletAddress,
+		ParentCommentID:     c.ParentCommentID,
+		Content:             c.Content,
+		Signature:           c.Signature,
+		EditedAt:            c.EditedAt,
+		DeletedAt:           c.DeletedAt,
+		HiddenAt:            c.HiddenAt,
+		HiddenBy:            c.HiddenBy,
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
+	}
+}
@@ -0,0 +1,164 @@
+// Package memory berisi implementasi in-memory dari interface di package repository.
+// Semua repository berbagi satu DB sehingga relasi antar tabel (investor dari ledger,
+// cascade saat project dipurge, foreign key) berperilaku seperti di Postgres.
+// Ditujukan untuk test HTTP tanpa database; aman dipakai dari banyak goroutine.
+//
+// Semua data disalin (termasuk string) sebelum disimpan, sehingga aman dipakai dengan konfigurasi
+// Fiber default yang memakai ulang buffer request untuk string dari c.Params, c.Query dan lainnya.
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// DB adalah "database" in-memory yang dipakai bersama oleh semua repository di package ini
+type DB struct {
+	mu sync.RWMutex
+
+	projects    map[uint64]*model.Project
+	links       map[uint64]*model.ExternalLink
+	coOwners    []*model.ProjectCoOwner
+	investments map[uint64]*model.Investment
+	comments    map[uint64]*model.Comment
+	revisions   map[uint64]*model.CommentRevision
+	reactions   map[uint64]*model.CommentReaction
+	reports     map[uint64]*model.CommentReport
+	profiles    map[string]*model.UserProfile
+	nonces      map[string]*model.AuthNonce
+	checkpoints map[string]*model.IndexerCheckpoint
+	pending     map[pendingKey]*model.IndexerPendingEvent
+
+	// sequences meniru BIGSERIAL per tabel
+	sequences map[string]uint64
+}
+
+// NewDB membuat DB kosong
+func NewDB() *DB {
+	return &DB{
+		projects:    map[uint64]*model.Project{},
+		links:       map[uint64]*model.ExternalLink{},
+		investments: map[uint64]*model.Investment{},
+		comments:    map[uint64]*model.Comment{},
+		revisions:   map[uint64]*model.CommentRevision{},
+		reactions:   map[uint64]*model.CommentReaction{},
+		reports:     map[uint64]*model.CommentReport{},
+		profiles:    map[string]*model.UserProfile{},
+		nonces:      map[string]*model.AuthNonce{},
+		checkpoints: map[string]*model.IndexerCheckpoint{},
+		pending:     map[pendingKey]*model.IndexerPendingEvent{},
+		sequences:   map[string]uint64{},
+	}
+}
+
+// nextID mengembalikan ID berikutnya untuk tabel. ID yang diisi manual (lihat useID)
+// ikut memajukan sequence agar tidak bentrok.
+func (db *DB) nextID(table string) uint64 {
+	db.sequences[table]++
+	return db.sequences[table]
+}
+
+// useID memakai id yang diberikan caller, atau mengambil dari sequence jika nol
+func (db *DB) useID(table string, id uint64) uint64 {
+	if id == 0 {
+		return db.nextID(table)
+	}
+	if id > db.sequences[table] {
+		db.sequences[table] = id
+	}
+	return id
+}
+
+// constraintError meniru pelanggaran constraint Postgres setelah diterjemahkan oleh
+// repository: error apperror yang membungkus pesan asli Postgres.
+type constraintError struct {
+	message   string
+	duplicate bool
+}
+
+func (e *constraintError) Error() string {
+	return e.message
+}
+
+// errDuplicate meniru unique violation
+func errDuplicate(constraint string) error {
+	return repository.ConstraintError(apperror.ErrConflict, constraint).Wrap(&constraintError{
+		message:   fmt.Sprintf("ERROR: duplicate key value violates unique constraint %q (SQLSTATE 23505)", constraint),
+		duplicate: true,
+	})
+}
+
+// errForeignKey meniru foreign key violation saat insert/update ke baris yang tidak ada
+func errForeignKey(table, constraint string) error {
+	return repository.ConstraintError(apperror.ErrNotFound, constraint).Wrap(&constraintError{
+		message: fmt.Sprintf("ERROR: insert or update on table %q violates foreign key constraint %q (SQLSTATE 23503)", table, constraint),
+	})
+}
+
+// isDuplicate mengecek apakah err adalah unique violation (untuk meniru ON CONFLICT DO NOTHING)
+func isDuplicate(err error) bool {
+	var ce *constraintError
+	return errors.As(err, &ce) && ce.duplicate
+}
+
+// sameFold membandingkan dua string seperti LOWER(a) = LOWER(b)
+func sameFold(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// detach mengembalikan salinan v yang tidak berbagi memori dengan caller: string disalin ke
+// memori baru dan pointer, slice serta map ikut disalin. String dari Fiber (mis. c.Params)
+// menunjuk ke buffer request yang dipakai ulang setelah request selesai, jadi semua yang
+// disimpan DB harus lewat detach.
+func detach[T any](v T) T {
+	return detachValue(reflect.ValueOf(&v).Elem()).Interface().(T)
+}
+
+func detachValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(strings.Clone(v.String())).Convert(v.Type())
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(detachValue(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v) // field unexported (mis. di time.Time) tidak berisi string dari caller
+		for i := 0; i < v.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(detachValue(v.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(detachValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			copied.SetMapIndex(detachValue(iter.Key()), detachValue(iter.Value()))
+		}
+		return copied
+	}
+	return v
+}
@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// ExternalLinkRepository adalah implementasi in-memory dari repository.ExternalLinkStore
+type ExternalLinkRepository struct {
+	db *DB
+}
+
+// NewExternalLinkRepository membuat instance baru dari ExternalLinkRepository
+func NewExternalLinkRepository(db *DB) *ExternalLinkRepository {
+	return &ExternalLinkRepository{db: db}
+}
+
+// WithContext mengembalikan repository yang sama; store in-memory tidak memakai context
+func (r *ExternalLinkRepository) WithContext(ctx context.Context) repository.ExternalLinkStore {
+	return r
+}
+
+var _ repository.ExternalLinkStore = (*ExternalLinkRepository)(nil)
+
+// GetByProjectID mengambil semua external links untuk sebuah proyek, urut berdasarkan position
+func (r *ExternalLinkRepository) GetByProjectID(projectID uint64) ([]model.ExternalLink, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	return r.db.projectLinks(projectID), nil
+}
+
+// GetByID mengambil external link berdasarkan ID
+func (r *ExternalLinkRepository) GetByID(id uint64) (*model.ExternalLink, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	stored := r.db.links[id]
+	if stored == nil {
+		return nil, nil
+	}
+	link := *stored
+	return &link, nil
+}
+
+// NextPosition mengembalikan position untuk link yang ditambahkan di akhir daftar
+func (r *ExternalLinkRepository) NextPosition(projectID uint64) (int, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	next := 0
+	for _, link := range r.db.links {
+		if link.ProjectID == projectID && link.Position >= next {
+			next = link.Position + 1
+		}
+	}
+	return next, nil
+}
+
+// Create membuat external link baru
+func (r *ExternalLinkRepository) Create(link *model.ExternalLink) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if link.ID != 0 && r.db.links[link.ID] != nil {
+		return errDuplicate("external_links_pkey")
+	}
+	if r.db.projects[link.ProjectID] == nil {
+		return errForeignKey("external_links", "fk_projects_links")
+	}
+	link.ID = r.db.useID("external_links", link.ID)
+	if link.Type == "" {
+		link.Type = "other"
+	}
+	now := time.Now()
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = now
+	}
+	if link.UpdatedAt.IsZero() {
+		link.UpdatedAt = now
+	}
+	stored := detach(*link)
+	r.db.links[link.ID] = &stored
+	r.db.touchProject(link.ProjectID)
+	return nil
+}
+
+// Update memperbarui external link
+func (r *ExternalLinkRepository) Update(link *model.ExternalLink) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.db.projects[link.ProjectID] == nil {
+		return errForeignKey("external_links", "fk_projects_links")
+	}
+	link.ID = r.db.useID("external_links", link.ID)
+	link.UpdatedAt = time.Now()
+	stored := detach(*link)
+	r.db.links[link.ID] = &stored
+	r.db.touchProject(link.ProjectID)
+	return nil
+}
+
+// Delete menghapus external link
+func (r *ExternalLinkRepository) Delete(id uint64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if link := r.db.links[id]; link != nil {
+		delete(r.db.links, id)
+		r.db.touchProject(link.ProjectID)
+	}
+	return nil
+}
+
+// DeleteByProjectID menghapus semua external links untuk sebuah proyek
+func (r *ExternalLinkRepository) DeleteByProjectID(projectID uint64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for id, link := range r.db.links {
+		if link.ProjectID == projectID {
+			delete(r.db.links, id)
+		}
+	}
+	r.db.touchProject(projectID)
+	return nil
+}
+
+// sortLinks mengurutkan links berdasarkan position lalu id
+func sortLinks(links []model.ExternalLink) {
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Position != links[j].Position {
+			return links[i].Position < links[j].Position
+		}
+		return links[i].ID < links[j].ID
+	})
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// IndexerCheckpointRepository adalah implementasi in-memory dari repository.IndexerCheckpointStore
+type IndexerCheckpointRepository struct {
+	db *DB
+}
+
+// NewIndexerCheckpointRepository membuat instance baru dari IndexerCheckpointRepository
+func NewIndexerCheckpointRepository(db *DB) *IndexerCheckpointRepository {
+	return &IndexerCheckpointRepository{db: db}
+}
+
+var _ repository.IndexerCheckpointStore = (*IndexerCheckpointRepository)(nil)
+
+// GetByName mengambil checkpoint berdasarkan nama indexer
+func (r *IndexerCheckpointRepository) GetByName(name string) (*model.IndexerCheckpoint, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	stored := r.db.checkpoints[name]
+	if stored == nil {
+		return nil, nil
+	}
+	checkpoint := *stored
+	return &checkpoint, nil
+}
+
+// pendingKey adalah primary key indexer_pending_events
+type pendingKey struct {
+	chainID  int64
+	txHash   string
+	logIndex uint
+}
+
+// SaveBatch menyimpan entri ledger hasil indexing, event untuk project yang belum ada, dan
+// memajukan checkpoint secara atomik. Entri yang sudah ada (chain_id, tx_hash, log_index sama) diabaikan;
+// yang dikembalikan adalah jumlah entri ledger yang benar-benar tercatat.
+func (r *IndexerCheckpointRepository) SaveBatch(checkpoint *model.IndexerCheckpoint, investments []model.Investment, pending []model.IndexerPendingEvent) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	// Validasi dulu agar batch tidak tersimpan sebagian, seperti rollback transaksi
+	for _, inv := range investments {
+		if r.db.projects[inv.ProjectID] == nil {
+			return 0, errForeignKey("investments", "fk_investments_project")
+		}
+	}
+	var inserted int64
+	for i := range investments {
+		err := r.db.insertInvestment(&investments[i])
+		if err != nil && !isDuplicate(err) {
+			return inserted, err
+		}
+		if err == nil {
+			inserted++
+		}
+	}
+
+	for _, event := range pending {
+		key := pendingKey{chainID: event.ChainID, txHash: event.TxHash, logIndex: event.LogIndex}
+		if r.db.pending[key] == nil {
+			event.CreatedAt = time.Now()
+			r.db.pending[key] = &event
+		}
+	}
+
+	checkpoint.UpdatedAt = time.Now()
+	stored := detach(*checkpoint)
+	r.db.checkpoints[stored.Name] = &stored
+	return inserted, nil
+}
+
+// PromotePending memindahkan event tertunda milik chainID yang project-nya sekarang ada ke ledger
+// investasi, dan mengembalikan jumlah entri ledger yang tercatat
+func (r *IndexerCheckpointRepository) PromotePending(chainID int64) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var promoted int64
+	for key, event := range r.db.pending {
+		if key.chainID != chainID || r.db.projects[event.ProjectID] == nil {
+			continue
+		}
+		investment := event.Investment()
+		err := r.db.insertInvestment(&investment)
+		if err != nil && !isDuplicate(err) {
+			return promoted, err
+		}
+		if err == nil {
+			promoted++
+		}
+		delete(r.db.pending, key)
+	}
+	return promoted, nil
+}
@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// InvestmentRepository adalah implementasi in-memory dari repository.InvestmentStore
+type InvestmentRepository struct {
+	db *DB
+}
+
+// NewInvestmentRepository membuat instance baru dari InvestmentRepository
+func NewInvestmentRepository(db *DB) *InvestmentRepository {
+	return &InvestmentRepository{db: db}
+}
+
+// WithContext mengembalikan repository yang sama; store in-memory tidak memakai context
+func (r *InvestmentRepository) WithContext(ctx context.Context) repository.InvestmentStore {
+	return r
+}
+
+var _ repository.InvestmentStore = (*InvestmentRepository)(nil)
+
+// Create mencatat investasi baru
+func (r *InvestmentRepository) Create(investment *model.Investment) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	return r.db.insertInvestment(investment)
+}
+
+// ListByProject mengambil investasi sebuah project, terbaru lebih dulu
+func (r *InvestmentRepository) ListByProject(projectID uint64, params repository.InvestmentListParams) ([]model.Investment, int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	investments := []model.Investment{}
+	for _, inv := range r.db.investments {
+		if inv.ProjectID != projectID {
+			continue
+		}
+		if params.WalletAddress != "" && !sameFold(inv.WalletAddress, params.WalletAddress) {
+			continue
+		}
+		investments = append(investments, *inv)
+	}
+	sort.Slice(investments, func(i, j int) bool {
+		if !investments[i].InvestedAt.Equal(investments[j].InvestedAt) {
+			return investments[i].InvestedAt.After(investments[j].InvestedAt)
+		}
+		return investments[i].ID > investments[j].ID
+	})
+
+	total := int64(len(investments))
+	if params.Offset >= len(investments) {
+		return []model.Investment{}, total, nil
+	}
+	investments = investments[params.Offset:]
+	if params.Limit > 0 && len(investments) > params.Limit {
+		investments = investments[:params.Limit]
+	}
+	return investments, total, nil
+}
+
+// Summary menghitung agregat ledger investasi sebuah project.
+// Total nominal adalah nilai bersih (investasi dikurangi refund).
+func (r *InvestmentRepository) Summary(projectID uint64) (*model.InvestmentSummary, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	type totalKey struct {
+		chainID int64
+		token   string
+	}
+	summary := &model.InvestmentSummary{ProjectID: projectID, Totals: []model.InvestmentTotal{}}
+	investors := map[string]bool{}
+	totals := map[totalKey]*big.Int{}
+
+	for _, inv := range r.db.investments {
+		if inv.ProjectID != projectID {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(inv.Amount, 10)
+		if !ok {
+			amount = new(big.Int)
+		}
+		key := totalKey{chainID: inv.ChainID, token: inv.TokenAddress}
+		if totals[key] == nil {
+			totals[key] = new(big.Int)
+		}
+
+		if inv.Kind == model.InvestmentKindRefund {
+			summary.RefundCount++
+			totals[key].Sub(totals[key], amount)
+			continue
+		}
+		totals[key].Add(totals[key], amount)
+		summary.InvestmentCount++
+		investors[strings.ToLower(inv.WalletAddress)] = true
+		investedAt := inv.InvestedAt
+		if summary.FirstInvestedAt == nil || investedAt.Before(*summary.FirstInvestedAt) {
+			summary.FirstInvestedAt = &investedAt
+		}
+		if summary.LastInvestedAt == nil || investedAt.After(*summary.LastInvestedAt) {
+			summary.LastInvestedAt = &investedAt
+		}
+	}
+	summary.InvestorCount = int64(len(investors))
+
+	for key, amount := range totals {
+		summary.Totals = append(summary.Totals, model.InvestmentTotal{
+			ChainID:      key.chainID,
+			TokenAddress: key.token,
+			Amount:       amount.String(),
+		})
+	}
+	sort.Slice(summary.Totals, func(i, j int) bool {
+		if summary.Totals[i].ChainID != summary.Totals[j].ChainID {
+			return summary.Totals[i].ChainID < summary.Totals[j].ChainID
+		}
+		return summary.Totals[i].TokenAddress < summary.Totals[j].TokenAddress
+	})
+	return summary, nil
+}
+
+// insertInvestment menyimpan entri ledger dengan constraint yang sama seperti tabel investments.
+// Caller harus memegang lock.
+func (db *DB) insertInvestment(investment *model.Investment) error {
+	if db.projects[investment.ProjectID] == nil {
+		return errForeignKey("investments", "fk_investments_project")
+	}
+	if investment.Source == "" {
+		investment.Source = model.InvestmentSourceManual
+	}
+	if investment.Source == model.InvestmentSourceManual && investment.TxHash != nil {
+		return apperror.Validation(`new row for relation "investments" violates check constraint "chk_investments_manual_without_tx"`)
+	}
+	if investment.TxHash != nil {
+		for _, inv := range db.investments {
+			if inv.TxHash != nil && *inv.TxHash == *investment.TxHash &&
+				inv.ChainID == investment.ChainID && inv.LogIndex == investment.LogIndex {
+				return errDuplicate("uidx_investments_chain_tx_log")
+			}
+		}
+	}
+
+	investment.ID = db.useID("investments", investment.ID)
+	if investment.Kind == "" {
+		investment.Kind = model.InvestmentKindInvestment
+	}
+	// NUMERIC menormalkan nilai, mis. "007" menjadi "7"
+	if amount, ok := new(big.Int).SetString(investment.Amount, 10); ok {
+		investment.Amount = amount.String()
+	} else {
+		investment.Amount = "0"
+	}
+	if investment.CreatedAt.IsZero() {
+		investment.CreatedAt = time.Now()
+	}
+	stored := detach(*investment)
+	db.investments[investment.ID] = &stored
+	return nil
+}
@@ -0,0 +1,627 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ProjectRepository adalah implementasi in-memory dari repository.ProjectStore
+type ProjectRepository struct {
+	db *DB
+}
+
+// NewProjectRepository membuat instance baru dari ProjectRepository
+func NewProjectRepository(db *DB) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+// WithContext mengembalikan repository yang sama; store in-memory tidak memakai context
+func (r *ProjectRepository) WithContext(ctx context.Context) repository.ProjectStore {
+	return r
+}
+
+var _ repository.ProjectStore = (*ProjectRepository)(nil)
+
+// projectColumns adalah kolom tabel projects yang boleh muncul di UpdatePartial
+// (nama kolom sama dengan tag json)
+var projectColumns = func() map[string]bool {
+	columns := map[string]bool{}
+	t := reflect.TypeOf(model.Project{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || field.Tag.Get("gorm") == "-" || field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+			continue
+		}
+		columns[name] = true
+	}
+	return columns
+}()
+
+// GetAll mengambil semua proyek
+func (r *ProjectRepository) GetAll() ([]model.Project, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	projects := []model.Project{}
+	for _, stored := range r.db.projects {
+		if stored.DeletedAt.Valid {
+			continue
+		}
+		projects = append(projects, r.db.loadProject(stored, true))
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ID < projects[j].ID })
+	return projects, nil
+}
+
+// GetByID mengambil proyek berdasarkan ID
+func (r *ProjectRepository) GetByID(id uint64) (*model.Project, error) {
+	return r.get(id, false)
+}
+
+// GetByIDWithDeleted mengambil proyek berdasarkan ID termasuk yang sudah di-soft delete
+func (r *ProjectRepository) GetByIDWithDeleted(id uint64) (*model.Project, error) {
+	return r.get(id, true)
+}
+
+func (r *ProjectRepository) get(id uint64, includeDeleted bool) (*model.Project, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	stored := r.db.project(id, includeDeleted)
+	if stored == nil {
+		return nil, nil
+	}
+	project := r.db.loadProject(stored, true)
+	return &project, nil
+}
+
+// Create membuat proyek baru beserta links-nya
+func (r *ProjectRepository) Create(project *model.Project) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if project.ID != 0 && r.db.projects[project.ID] != nil {
+		return errDuplicate("projects_pkey")
+	}
+	project.ID = r.db.useID("projects", project.ID)
+	if project.Status == "" {
+		project.Status = model.ProjectStatusDraft
+	}
+	if project.Currency == "" {
+		project.Currency = "ETH"
+	}
+	if project.CurrencyChainID == 0 {
+		project.CurrencyChainID = 1
+	}
+	if project.Version == 0 {
+		project.Version = 1
+	}
+	now := time.Now()
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = now
+	}
+	if project.UpdatedAt.IsZero() {
+		project.UpdatedAt = now
+	}
+
+	r.db.projects[project.ID] = storedProject(project)
+	r.db.insertLinks(project)
+	r.db.attachDerived(project)
+	return nil
+}
+
+// Update mengganti semua field proyek dan links-nya jika versinya masih project.Version,
+// lalu menaikkan versinya. Creator dan created_at tidak pernah diganti, dan status hanya
+// boleh berubah lewat TransitionStatus.
+func (r *ProjectRepository) Update(project *model.Project) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing := r.db.project(project.ID, false)
+	if existing == nil {
+		return repository.ErrProjectNotFound
+	}
+	if existing.Version != project.Version {
+		return repository.ErrVersionMismatch
+	}
+	project.CreatorWalletAddress = existing.CreatorWalletAddress
+	project.Status = existing.Status
+	project.StatusChangedAt = existing.StatusChangedAt
+	project.CreatedAt = existing.CreatedAt
+	project.UpdatedAt = time.Now()
+	project.DeletedAt = gorm.DeletedAt{}
+	project.Version++
+
+	r.db.projects[project.ID] = storedProject(project)
+	for id, link := range r.db.links {
+		if link.ProjectID == project.ID {
+			delete(r.db.links, id)
+		}
+	}
+	r.db.insertLinks(project)
+	r.db.attachDerived(project)
+	return nil
+}
+
+// UpdatePartial menerapkan changes jika versinya masih version. Key changes.Columns adalah
+// nama kolom; kolom yang tidak dikenal menghasilkan error seperti di Postgres.
+func (r *ProjectRepository) UpdatePartial(id, version uint64, changes repository.ProjectChanges) (*model.Project, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored := r.db.project(id, false)
+	if stored == nil {
+		return nil, nil
+	}
+	if stored.Version != version {
+		return nil, repository.ErrVersionMismatch
+	}
+
+	updated, err := applyProjectColumns(*stored, changes.Columns)
+	if err != nil {
+		return nil, err
+	}
+	updated.UpdatedAt = time.Now()
+	updated.Version = version + 1
+	r.db.projects[id] = storedProject(&updated)
+
+	if changes.ReplaceLinks {
+		for linkID, link := range r.db.links {
+			if link.ProjectID == id {
+				delete(r.db.links, linkID)
+			}
+		}
+		updated.Links = changes.Links
+		r.db.insertLinks(&updated)
+	}
+
+	project := r.db.loadProject(r.db.projects[id], true)
+	return &project, nil
+}
+
+// Delete melakukan soft delete pada proyek
+func (r *ProjectRepository) Delete(id uint64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored := r.db.project(id, false)
+	if stored == nil {
+		return repository.ErrProjectNotFound
+	}
+	stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return nil
+}
+
+// Restore membatalkan soft delete proyek
+func (r *ProjectRepository) Restore(id uint64) (*model.Project, error) {
+	r.db.mu.Lock()
+	stored := r.db.project(id, true)
+	if stored == nil || !stored.DeletedAt.Valid {
+		r.db.mu.Unlock()
+		return nil, repository.ErrProjectNotDeleted
+	}
+	stored.DeletedAt = gorm.DeletedAt{}
+	stored.Version++
+	r.db.mu.Unlock()
+
+	return r.GetByID(id)
+}
+
+// PurgeDeleted menghapus permanen proyek yang di-soft delete sebelum waktu before
+// beserta semua data turunannya (seperti ON DELETE CASCADE)
+func (r *ProjectRepository) PurgeDeleted(before time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var purged int64
+	for id, stored := range r.db.projects {
+		if stored.DeletedAt.Valid && stored.DeletedAt.Time.Before(before) {
+			r.db.deleteProject(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// TransitionStatus memindahkan status project ke status to jika transisinya sah
+func (r *ProjectRepository) TransitionStatus(id uint64, to string) (*model.Project, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored := r.db.project(id, false)
+	if stored == nil {
+		return nil, nil
+	}
+	if !model.CanTransitionProjectStatus(stored.Status, to) {
+		return nil, repository.InvalidTransitionError(stored.Status, to)
+	}
+
+	now := time.Now()
+	stored.Status = to
+	stored.StatusChangedAt = &now
+	stored.UpdatedAt = now
+	stored.Version++
+
+	project := r.db.loadProject(stored, true)
+	return &project, nil
+}
+
+// SettleEnded memindahkan project live yang deadline-nya sudah lewat ke funded atau failed
+func (r *ProjectRepository) SettleEnded(now time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var settled int64
+	for _, stored := range r.db.projects {
+		if stored.Status != model.ProjectStatusLive || stored.DeletedAt.Valid || stored.EndDate == nil || stored.EndDate.After(now) {
+			continue
+		}
+
+		goal := big.NewInt(1)
+		if stored.GoalAmount != nil {
+			if parsed, ok := new(big.Int).SetString(*stored.GoalAmount, 10); ok {
+				goal = parsed
+			}
+		}
+		stored.Status = model.ProjectStatusFailed
+		if r.db.raised(stored).Cmp(goal) >= 0 {
+			stored.Status = model.ProjectStatusFunded
+		}
+		changedAt := now
+		stored.StatusChangedAt = &changedAt
+		stored.UpdatedAt = now
+		stored.Version++
+		settled++
+	}
+	return settled, nil
+}
+
+// Exists mengecek apakah project dengan ID tersebut ada
+func (r *ProjectRepository) Exists(id uint64) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	return r.db.project(id, false) != nil, nil
+}
+
+// ExistsWithDeleted mengecek keberadaan project termasuk yang sudah di-soft delete
+func (r *ProjectRepository) ExistsWithDeleted(id uint64) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	return r.db.project(id, true) != nil, nil
+}
+
+// AddInvestor menambahkan wallet address investor ke project sebagai entri ledger tanpa nominal
+func (r *ProjectRepository) AddInvestor(projectID uint64, walletAddress string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.db.project(projectID, false) == nil {
+		return repository.ErrProjectNotFound
+	}
+	for _, inv := range r.db.investments {
+		if inv.ProjectID == projectID && sameFold(inv.WalletAddress, walletAddress) {
+			return repository.ErrInvestorExists
+		}
+	}
+
+	return r.db.insertInvestment(&model.Investment{
+		ProjectID:     projectID,
+		WalletAddress: walletAddress,
+		Kind:          model.InvestmentKindInvestment,
+		Amount:        "0",
+		InvestedAt:    time.Now(),
+	})
+}
+
+// RemoveInvestor menghapus entri ledger manual (tanpa tx_hash) milik wallet address investor
+// dari project; repository.ErrOnChainInvestor jika wallet punya entri on-chain
+func (r *ProjectRepository) RemoveInvestor(projectID uint64, walletAddress string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.db.project(projectID, false) == nil {
+		return repository.ErrProjectNotFound
+	}
+	for _, inv := range r.db.investments {
+		if inv.ProjectID == projectID && sameFold(inv.WalletAddress, walletAddress) && inv.Source == model.InvestmentSourceIndexed {
+			return repository.ErrOnChainInvestor
+		}
+	}
+	for id, inv := range r.db.investments {
+		if inv.ProjectID == projectID && sameFold(inv.WalletAddress, walletAddress) && inv.Source == model.InvestmentSourceManual {
+			delete(r.db.investments, id)
+		}
+	}
+	return nil
+}
+
+// GetInvestors mengambil semua investor wallet addresses (distinct) untuk project dari ledger
+func (r *ProjectRepository) GetInvestors(projectID uint64) ([]string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	if r.db.project(projectID, false) == nil {
+		return nil, repository.ErrProjectNotFound
+	}
+	return r.db.investors(projectID), nil
+}
+
+// IsCoOwner mengecek apakah wallet adalah co-owner dari project
+func (r *ProjectRepository) IsCoOwner(projectID uint64, walletAddress string) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	return r.db.coOwnerIndex(projectID, walletAddress) >= 0, nil
+}
+
+// GetCoOwners mengambil semua co-owner untuk project
+func (r *ProjectRepository) GetCoOwners(projectID uint64) ([]model.ProjectCoOwner, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	coOwners := []model.ProjectCoOwner{}
+	for _, co := range r.db.coOwners {
+		if co.ProjectID == projectID {
+			coOwners = append(coOwners, *co)
+		}
+	}
+	sort.SliceStable(coOwners, func(i, j int) bool {
+		return coOwners[i].CreatedAt.Before(coOwners[j].CreatedAt)
+	})
+	return coOwners, nil
+}
+
+// AddCoOwner menambahkan co-owner ke project
+func (r *ProjectRepository) AddCoOwner(coOwner *model.ProjectCoOwner) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.db.coOwnerIndex(coOwner.ProjectID, coOwner.WalletAddress) >= 0 {
+		return repository.ErrCoOwnerExists
+	}
+	if r.db.projects[coOwner.ProjectID] == nil {
+		return errForeignKey("project_co_owners", "fk_project_co_owners_project")
+	}
+	if coOwner.CreatedAt.IsZero() {
+		coOwner.CreatedAt = time.Now()
+	}
+	stored := detach(*coOwner)
+	r.db.coOwners = append(r.db.coOwners, &stored)
+	return nil
+}
+
+// RemoveCoOwner menghapus co-owner dari project
+func (r *ProjectRepository) RemoveCoOwner(projectID uint64, walletAddress string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	i := r.db.coOwnerIndex(projectID, walletAddress)
+	if i < 0 {
+		return repository.ErrCoOwnerNotFound
+	}
+	r.db.coOwners = append(r.db.coOwners[:i], r.db.coOwners[i+1:]...)
+	return nil
+}
+
+// project mengembalikan baris project yang tersimpan, atau nil jika tidak ada.
+// Caller harus memegang lock.
+func (db *DB) project(id uint64, includeDeleted bool) *model.Project {
+	stored := db.projects[id]
+	if stored == nil || (stored.DeletedAt.Valid && !includeDeleted) {
+		return nil
+	}
+	return stored
+}
+
+// touchProject menaikkan versi project seperti repository.touchProject. Caller harus memegang lock.
+func (db *DB) touchProject(id uint64) {
+	if stored := db.project(id, false); stored != nil {
+		stored.Version++
+	}
+}
+
+// loadProject menyalin baris project lengkap dengan field turunan, seperti hasil query
+// dengan Preload("Links") jika withLinks. Caller harus memegang lock.
+func (db *DB) loadProject(stored *model.Project, withLinks bool) model.Project {
+	project := *stored
+	if withLinks {
+		project.Links = db.projectLinks(project.ID)
+	}
+	db.attachDerived(&project)
+	return project
+}
+
+// attachDerived mengisi investor dan progres pendanaan project dari ledger
+func (db *DB) attachDerived(project *model.Project) {
+	project.InvestorWalletAddresses = db.investors(project.ID)
+	project.FundingProgress = repository.ComputeFundingProgress(project, db.raised(project).String(), time.Now())
+}
+
+// investors mengembalikan wallet investor unik (case-insensitive), diurutkan berdasarkan
+// investasi pertama setiap wallet
+func (db *DB) investors(projectID uint64) model.StringArray {
+	type investor struct {
+		wallet string
+		first  time.Time
+		id     uint64
+	}
+	byWallet := map[string]*investor{}
+	for _, inv := range db.investments {
+		if inv.ProjectID != projectID || inv.Kind != model.InvestmentKindInvestment {
+			continue
+		}
+		key := strings.ToLower(inv.WalletAddress)
+		entry := byWallet[key]
+		if entry == nil {
+			byWallet[key] = &investor{wallet: inv.WalletAddress, first: inv.InvestedAt, id: inv.ID}
+			continue
+		}
+		if inv.WalletAddress < entry.wallet {
+			entry.wallet = inv.WalletAddress
+		}
+		if inv.InvestedAt.Before(entry.first) {
+			entry.first = inv.InvestedAt
+		}
+		if inv.ID < entry.id {
+			entry.id = inv.ID
+		}
+	}
+
+	list := make([]*investor, 0, len(byWallet))
+	for _, entry := range byWallet {
+		list = append(list, entry)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].first.Equal(list[j].first) {
+			return list[i].first.Before(list[j].first)
+		}
+		return list[i].id < list[j].id
+	})
+
+	wallets := make(model.StringArray, len(list))
+	for i, entry := range list {
+		wallets[i] = entry.wallet
+	}
+	return wallets
+}
+
+// raised menghitung total bersih entri indexer project dalam mata uang project (chain dan token yang sama)
+func (db *DB) raised(project *model.Project) *big.Int {
+	total := new(big.Int)
+	for _, inv := range db.investments {
+		if inv.ProjectID != project.ID || inv.Source != model.InvestmentSourceIndexed ||
+			inv.ChainID != project.CurrencyChainID || !sameFold(inv.TokenAddress, project.CurrencyTokenAddress) {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(inv.Amount, 10)
+		if !ok {
+			continue
+		}
+		if inv.Kind == model.InvestmentKindRefund {
+			total.Sub(total, amount)
+		} else {
+			total.Add(total, amount)
+		}
+	}
+	return total
+}
+
+// projectLinks mengembalikan links project, urut berdasarkan position lalu id
+func (db *DB) projectLinks(projectID uint64) []model.ExternalLink {
+	links := []model.ExternalLink{}
+	for _, link := range db.links {
+		if link.ProjectID == projectID {
+			links = append(links, *link)
+		}
+	}
+	sortLinks(links)
+	return links
+}
+
+// insertLinks menyimpan project.Links sebagai baris baru milik project
+func (db *DB) insertLinks(project *model.Project) {
+	now := time.Now()
+	for i := range project.Links {
+		link := &project.Links[i]
+		link.ID = db.nextID("external_links")
+		link.ProjectID = project.ID
+		if link.Type == "" {
+			link.Type = "other"
+		}
+		if link.CreatedAt.IsZero() {
+			link.CreatedAt = now
+		}
+		if link.UpdatedAt.IsZero() {
+			link.UpdatedAt = now
+		}
+		stored := detach(*link)
+		db.links[link.ID] = &stored
+	}
+}
+
+// deleteProject menghapus permanen project beserta semua baris yang mereferensikannya
+func (db *DB) deleteProject(id uint64) {
+	delete(db.projects, id)
+	for linkID, link := range db.links {
+		if link.ProjectID == id {
+			delete(db.links, linkID)
+		}
+	}
+	coOwners := db.coOwners[:0]
+	for _, co := range db.coOwners {
+		if co.ProjectID != id {
+			coOwners = append(coOwners, co)
+		}
+	}
+	db.coOwners = coOwners
+	for invID, inv := range db.investments {
+		if inv.ProjectID == id {
+			delete(db.investments, invID)
+		}
+	}
+	for commentID, comment := range db.comments {
+		if comment.ProjectID == id {
+			db.deleteComment(commentID)
+		}
+	}
+}
+
+func (db *DB) coOwnerIndex(projectID uint64, walletAddress string) int {
+	for i, co := range db.coOwners {
+		if co.ProjectID == projectID && sameFold(co.WalletAddress, walletAddress) {
+			return i
+		}
+	}
+	return -1
+}
+
+// storedProject menyalin project tanpa relasi dan field turunan
+func storedProject(project *model.Project) *model.Project {
+	stored := *project
+	stored.Links = nil
+	stored.InvestorWalletAddresses = nil
+	stored.FundingProgress = nil
+	stored = detach(stored)
+	return &stored
+}
+
+// applyProjectColumns menerapkan updates (nama kolom -> nilai) ke salinan project
+func applyProjectColumns(project model.Project, updates map[string]interface{}) (model.Project, error) {
+	for column := range updates {
+		if !projectColumns[column] {
+			return project, fmt.Errorf("ERROR: column %q of relation \"projects\" does not exist (SQLSTATE 42703)", column)
+		}
+	}
+
+	raw, err := json.Marshal(project)
+	if err != nil {
+		return project, err
+	}
+	merged := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return project, err
+	}
+	for column, value := range updates {
+		merged[column] = value
+	}
+	if raw, err = json.Marshal(merged); err != nil {
+		return project, err
+	}
+
+	var updated model.Project
+	if err := json.Unmarshal(raw, &updated); err != nil {
+		return project, err
+	}
+	updated.DeletedAt = project.DeletedAt
+	return *storedProject(&updated), nil
+}
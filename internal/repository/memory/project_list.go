@@ -0,0 +1,263 @@
+package memory
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// List mengambil satu halaman project dengan keyset pagination pada (kolom sort, id).
+// Cursor kompatibel dengan implementasi Postgres.
+func (r *ProjectRepository) List(params repository.ProjectListParams) (*repository.ProjectPage, error) {
+	if params.Sort == "" {
+		params.Sort = "created_at"
+	}
+	if !repository.ValidProjectSort(params.Sort) {
+		return nil, fmt.Errorf("unsupported sort %q", params.Sort)
+	}
+	if params.Limit <= 0 {
+		params.Limit = repository.DefaultProjectPageSize
+	}
+	if params.Limit > repository.MaxProjectPageSize {
+		params.Limit = repository.MaxProjectPageSize
+	}
+
+	var after interface{}
+	var afterID uint64
+	if params.Cursor != "" {
+		value, id, err := repository.DecodeProjectCursor(params.Cursor, params.Sort, params.Desc)
+		if err != nil {
+			return nil, err
+		}
+		after, afterID = value, id
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var matched []model.Project
+	for _, stored := range r.db.projects {
+		if stored.DeletedAt.Valid || !r.db.matchesProjectFilters(stored, params) {
+			continue
+		}
+		matched = append(matched, r.db.loadProject(stored, true))
+	}
+
+	// position membandingkan a dengan (value, id) dalam urutan yang diminta:
+	// negatif jika a muncul lebih dulu, positif jika a muncul setelahnya
+	position := func(a model.Project, value interface{}, id uint64) int {
+		cmp := compareSortValues(projectSortValue(a, params.Sort), value)
+		if cmp == 0 {
+			cmp = compareSortValues(a.ID, id)
+		}
+		if params.Desc {
+			return -cmp
+		}
+		return cmp
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return position(matched[i], projectSortValue(matched[j], params.Sort), matched[j].ID) < 0
+	})
+
+	page := &repository.ProjectPage{Projects: []model.Project{}, Total: int64(len(matched))}
+	for _, project := range matched {
+		if after != nil && position(project, after, afterID) <= 0 {
+			continue
+		}
+		if len(page.Projects) == params.Limit {
+			if !repository.ProjectSortHasCursor(params.Sort) {
+				break
+			}
+			cursor, err := repository.EncodeProjectCursor(params.Sort, params.Desc, page.Projects[len(page.Projects)-1])
+			if err != nil {
+				return nil, err
+			}
+			page.NextCursor = cursor
+			break
+		}
+		page.Projects = append(page.Projects, project)
+	}
+	return page, nil
+}
+
+// Search mencari project berdasarkan title, description, developer_name dan genre.
+// Berbeda dengan full-text search Postgres, pencocokan di sini berupa substring
+// case-insensitive untuk setiap kata (tanpa stemming); rank adalah jumlah kemunculan kata.
+func (r *ProjectRepository) Search(params repository.ProjectSearchParams) ([]repository.ProjectSearchHit, int64, error) {
+	if params.Language != "" {
+		if _, ok := repository.SearchLanguages[params.Language]; !ok {
+			return nil, 0, fmt.Errorf("unsupported search language %q", params.Language)
+		}
+	}
+	if params.Limit <= 0 || params.Limit > repository.MaxProjectPageSize {
+		params.Limit = repository.DefaultProjectPageSize
+	}
+
+	var terms []string
+	for _, term := range strings.Fields(strings.ToLower(params.Query)) {
+		term = strings.Trim(term, `"'-`)
+		if term != "" && term != "or" {
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) == 0 {
+		return []repository.ProjectSearchHit{}, 0, nil
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var hits []repository.ProjectSearchHit
+	for _, stored := range r.db.projects {
+		if stored.DeletedAt.Valid || !containsStatus(params.Statuses, stored.Status) {
+			continue
+		}
+		document := strings.ToLower(strings.Join([]string{stored.Title, stored.Description, stored.DeveloperName, stored.Genre}, " "))
+		rank := 0
+		for _, term := range terms {
+			count := strings.Count(document, term)
+			if count == 0 {
+				rank = 0
+				break
+			}
+			rank += count
+		}
+		if rank == 0 {
+			continue
+		}
+		hits = append(hits, repository.ProjectSearchHit{
+			Project:              r.db.loadProject(stored, true),
+			Rank:                 float64(rank),
+			TitleHighlight:       highlightTerms(stored.Title, terms),
+			DescriptionHighlight: highlightTerms(stored.Description, terms),
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].Project.ID > hits[j].Project.ID
+	})
+
+	total := int64(len(hits))
+	if params.Offset >= len(hits) {
+		return []repository.ProjectSearchHit{}, total, nil
+	}
+	hits = hits[params.Offset:]
+	if len(hits) > params.Limit {
+		hits = hits[:params.Limit]
+	}
+	return hits, total, nil
+}
+
+// matchesProjectFilters menerapkan filter ProjectListParams seperti applyProjectFilters
+func (db *DB) matchesProjectFilters(p *model.Project, params repository.ProjectListParams) bool {
+	if params.Genre != "" && !sameFold(p.Genre, params.Genre) {
+		return false
+	}
+	if params.GameType != "" && !sameFold(p.GameType, params.GameType) {
+		return false
+	}
+	if params.CreatorWalletAddress != "" && !sameFold(p.CreatorWalletAddress, params.CreatorWalletAddress) {
+		return false
+	}
+	if params.DeveloperName != "" && !strings.Contains(strings.ToLower(p.DeveloperName), strings.ToLower(params.DeveloperName)) {
+		return false
+	}
+	if !model.ProjectStatusIsPublic(p.Status) {
+		viewer := params.ViewerWalletAddress
+		if viewer == "" || (!sameFold(p.CreatorWalletAddress, viewer) && db.coOwnerIndex(p.ID, viewer) < 0) {
+			return false
+		}
+	}
+	return containsStatus(params.Statuses, p.Status)
+}
+
+// containsStatus mengecek filter status; daftar kosong berarti semua status
+func containsStatus(statuses []string, status string) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// projectSortValue mengembalikan nilai kolom sort project dengan tipe yang sama seperti
+// hasil repository.DecodeProjectCursor
+func projectSortValue(p model.Project, sort string) interface{} {
+	switch sort {
+	case "created_at":
+		return p.CreatedAt
+	case "updated_at":
+		return p.UpdatedAt
+	case "title":
+		return p.Title
+	case "investor_count":
+		return len(p.InvestorWalletAddresses)
+	}
+	return p.ID
+}
+
+// compareSortValues membandingkan dua nilai sort bertipe sama; hasilnya -1, 0 atau 1
+func compareSortValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case time.Time:
+		bv := b.(time.Time)
+		switch {
+		case av.Before(bv):
+			return -1
+		case av.After(bv):
+			return 1
+		}
+	case string:
+		return strings.Compare(av, b.(string))
+	case int:
+		bv := b.(int)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	case uint64:
+		bv := b.(uint64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	}
+	return 0
+}
+
+// highlightTerms membungkus setiap kemunculan kata dengan <mark> seperti ts_headline.
+// Teks di luar dan di dalam <mark> di-escape HTML seperti implementasi Postgres.
+func highlightTerms(text string, terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	pattern := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		b.WriteString("<mark>" + html.EscapeString(text[loc[0]:loc[1]]) + "</mark>")
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
+)
+
+// UserProfileRepository adalah implementasi in-memory dari repository.UserProfileStore
+type UserProfileRepository struct {
+	db *DB
+}
+
+// NewUserProfileRepository membuat instance baru dari UserProfileRepository
+func NewUserProfileRepository(db *DB) *UserProfileRepository {
+	return &UserProfileRepository{db: db}
+}
+
+// WithContext mengembalikan repository yang sama; store in-memory tidak memakai context
+func (r *UserProfileRepository) WithContext(ctx context.Context) repository.UserProfileStore {
+	return r
+}
+
+var _ repository.UserProfileStore = (*UserProfileRepository)(nil)
+
+// GetByWalletAddress mengambil profil berdasarkan wallet address
+func (r *UserProfileRepository) GetByWalletAddress(walletAddress string) (*model.UserProfile, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	stored := r.db.profiles[walletAddress]
+	if stored == nil {
+		return nil, nil
+	}
+	profile := *stored
+	return &profile, nil
+}
+
+// Create membuat profil baru
+func (r *UserProfileRepository) Create(profile *model.UserProfile) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.db.profiles[profile.WalletAddress] != nil {
+		return errDuplicate("user_profiles_pkey")
+	}
+	return r.db.saveProfile(profile, nil)
+}
+
+// Update memperbarui profil (membuatnya jika belum ada, seperti Save di GORM)
+func (r *UserProfileRepository) Update(profile *model.UserProfile) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	return r.db.saveProfile(profile, r.db.profiles[profile.WalletAddress])
+}
+
+// Upsert membuat atau memperbarui profil (create or update) tanpa mengubah kyc_status
+// profil yang sudah ada
+func (r *UserProfileRepository) Upsert(profile *model.UserProfile) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing := r.db.profiles[profile.WalletAddress]
+	profile.KYCStatus = model.KYCStatusUnverified
+	if existing != nil {
+		profile.KYCStatus = existing.KYCStatus
+	}
+	return r.db.saveProfile(profile, existing)
+}
+
+// Delete menghapus profil
+func (r *UserProfileRepository) Delete(walletAddress string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	delete(r.db.profiles, walletAddress)
+	return nil
+}
+
+// saveProfile menyimpan profil dengan memeriksa constraint unik username dan email.
+// existing adalah baris lama dengan wallet yang sama (nil jika insert). Caller harus memegang lock.
+func (db *DB) saveProfile(profile *model.UserProfile, existing *model.UserProfile) error {
+	for wallet, other := range db.profiles {
+		if wallet == profile.WalletAddress {
+			continue
+		}
+		if other.Username == profile.Username {
+			return errDuplicate("uni_user_profiles_username")
+		}
+		if other.Email == profile.Email {
+			return errDuplicate("uni_user_profiles_email")
+		}
+	}
+
+	now := time.Now()
+	if existing != nil {
+		profile.CreatedAt = existing.CreatedAt
+	} else if profile.CreatedAt.IsZero() {
+		profile.CreatedAt = now
+	}
+	if profile.KYCStatus == "" {
+		profile.KYCStatus = model.KYCStatusUnverified
+	}
+	profile.UpdatedAt = now
+
+	stored := detach(*profile)
+	db.profiles[stored.WalletAddress] = &stored
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+)
+
+// attachFundingProgress mengisi FundingProgress setiap project.
+// Raised hanya menghitung entri ledger hasil indexer dengan chain dan token yang sama dengan mata
+// uang project, sehingga investasi dalam token lain, native coin dari chain lain, maupun entri
+// manual tanpa bukti on-chain tidak tercampur ke progress bar.
+// InvestorWalletAddresses harus sudah terisi (lihat attachInvestors).
+func (r *ProjectRepository) attachFundingProgress(projects []*model.Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+
+	var rows []struct {
+		ProjectID uint64
+		Raised    string
+	}
+	err := r.db.Table("investments AS i").
+		Select("i.project_id, SUM(CASE WHEN i.kind = ? THEN -i.amount ELSE i.amount END)::text AS raised", model.InvestmentKindRefund).
+		Joins("JOIN projects p ON p.id = i.project_id AND i.chain_id = p.currency_chain_id AND LOWER(i.token_address) = LOWER(p.currency_token_address)").
+		Where("i.project_id IN ? AND i.source = ?", ids, model.InvestmentSourceIndexed).
+		Group("i.project_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	raised := make(map[uint64]string, len(rows))
+	for _, row := range rows {
+		raised[row.ProjectID] = row.Raised
+	}
+
+	now := time.Now()
+	for _, p := range projects {
+		p.FundingProgress = ComputeFundingProgress(p, raised[p.ID], now)
+	}
+	return nil
+}
+
+// ComputeFundingProgress menghitung ringkasan progres pendanaan project pada waktu now dari
+// total bersih raised (desimal, kosong berarti 0). InvestorWalletAddresses harus sudah terisi.
+func ComputeFundingProgress(p *model.Project, raised string, now time.Time) *model.FundingProgress {
+	amount, ok := new(big.Int).SetString(raised, 10)
+	if !ok || amount.Sign() < 0 {
+		amount = new(big.Int)
+	}
+
+	progress := &model.FundingProgress{
+		Raised:      amount.String(),
+		Goal:        p.GoalAmount,
+		BackerCount: len(p.InvestorWalletAddresses),
+	}
+
+	if p.GoalAmount != nil {
+		if goal, ok := new(big.Float).SetString(*p.GoalAmount); ok && goal.Sign() > 0 {
+			percent, _ := new(big.Float).Quo(new(big.Float).Mul(new(big.Float).SetInt(amount), big.NewFloat(100)), goal).Float64()
+			progress.Percent = &percent
+		}
+	}
+
+	if p.EndDate != nil {
+		remaining := int64(p.EndDate.Sub(now) / time.Second)
+		if remaining < 0 {
+			remaining = 0
+		}
+		progress.TimeRemainingSeconds = &remaining
+		progress.Ended = remaining == 0
+	}
+
+	return progress
+}
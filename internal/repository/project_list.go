@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"gorm.io/gorm"
+)
+
+// Batas ukuran halaman untuk listing project
+const (
+	DefaultProjectPageSize = 20
+	MaxProjectPageSize     = 100
+)
+
+// ErrInvalidCursor dikembalikan ketika cursor tidak bisa didekode atau tidak cocok dengan sort
+var ErrInvalidCursor = apperror.Validation("Invalid cursor")
+
+// projectSortColumns memetakan nama sort publik ke ekspresi SQL
+var projectSortColumns = map[string]string{
+	"created_at":     "projects.created_at",
+	"updated_at":     "projects.updated_at",
+	"title":          "projects.title",
+	"investor_count": "(SELECT COUNT(DISTINCT LOWER(i.wallet_address)) FROM investments i WHERE i.project_id = projects.id AND i.kind = 'investment')",
+}
+
+// projectSortsWithoutCursor adalah sort yang nilainya dihitung saat query dan bisa berubah di antara
+// dua request. Keyset cursor di atas nilai seperti itu melewatkan atau menggandakan project, jadi
+// sort ini hanya mengembalikan satu halaman (top-N) tanpa next_cursor.
+var projectSortsWithoutCursor = map[string]bool{
+	"investor_count": true,
+}
+
+// ProjectListParams berisi opsi filter, sort dan pagination untuk listing project
+type ProjectListParams struct {
+	Limit  int
+	Sort   string // salah satu key projectSortColumns
+	Desc   bool
+	Cursor string
+
+	Genre                string
+	GameType             string
+	CreatorWalletAddress string
+	DeveloperName        string
+	Statuses             []string // kosong berarti semua status
+
+	// ViewerWalletAddress adalah wallet yang melihat listing. Draft dan review hanya ikut
+	// jika wallet ini creator atau co-owner project; kosong berarti hanya project publik.
+	ViewerWalletAddress string
+}
+
+// ProjectPage adalah satu halaman hasil listing project
+type ProjectPage struct {
+	Projects   []model.Project
+	NextCursor string
+	Total      int64
+}
+
+// projectCursor menyimpan posisi terakhir (nilai sort + id) agar pagination stabil
+type projectCursor struct {
+	Sort  string          `json:"s"`
+	Desc  bool            `json:"d"`
+	Value json.RawMessage `json:"v"`
+	ID    uint64          `json:"id"`
+}
+
+// ValidProjectSort mengecek apakah nama sort didukung
+func ValidProjectSort(sort string) bool {
+	_, ok := projectSortColumns[sort]
+	return ok
+}
+
+// ProjectSortHasCursor mengecek apakah sort mendukung pagination dengan cursor
+func ProjectSortHasCursor(sort string) bool {
+	return ValidProjectSort(sort) && !projectSortsWithoutCursor[sort]
+}
+
+// List mengambil satu halaman project dengan keyset pagination pada (kolom sort, id)
+func (r *ProjectRepository) List(params ProjectListParams) (*ProjectPage, error) {
+	if params.Sort == "" {
+		params.Sort = "created_at"
+	}
+	column, ok := projectSortColumns[params.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort %q", params.Sort)
+	}
+	if params.Limit <= 0 {
+		params.Limit = DefaultProjectPageSize
+	}
+	if params.Limit > MaxProjectPageSize {
+		params.Limit = MaxProjectPageSize
+	}
+
+	query := r.applyProjectFilters(r.db.Model(&model.Project{}), params)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	direction, comparator := "ASC", ">"
+	if params.Desc {
+		direction, comparator = "DESC", "<"
+	}
+
+	if params.Cursor != "" {
+		value, id, err := DecodeProjectCursor(params.Cursor, params.Sort, params.Desc)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(fmt.Sprintf("(%s, projects.id) %s (?, ?)", column, comparator), value, id)
+	}
+
+	var projects []model.Project
+	result := query.
+		Preload("Links", orderLinks).
+		Order(fmt.Sprintf("%s %s, projects.id %s", column, direction, direction)).
+		Limit(params.Limit + 1).
+		Find(&projects)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if err := r.attachDerivedToSlice(projects); err != nil {
+		return nil, err
+	}
+
+	page := &ProjectPage{Projects: projects, Total: total}
+	if len(projects) > params.Limit {
+		page.Projects = projects[:params.Limit]
+	}
+	if len(projects) > params.Limit && ProjectSortHasCursor(params.Sort) {
+		last := page.Projects[len(page.Projects)-1]
+		cursor, err := EncodeProjectCursor(params.Sort, params.Desc, last)
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+	}
+
+	return page, nil
+}
+
+// applyProjectFilters menambahkan klausa WHERE sesuai filter yang diisi
+func (r *ProjectRepository) applyProjectFilters(query *gorm.DB, params ProjectListParams) *gorm.DB {
+	if params.Genre != "" {
+		query = query.Where("LOWER(projects.genre) = LOWER(?)", params.Genre)
+	}
+	if params.GameType != "" {
+		query = query.Where("LOWER(projects.game_type) = LOWER(?)", params.GameType)
+	}
+	if params.CreatorWalletAddress != "" {
+		query = query.Where("LOWER(projects.creator_wallet_address) = LOWER(?)", params.CreatorWalletAddress)
+	}
+	if params.DeveloperName != "" {
+		query = query.Where("projects.developer_name ILIKE ?", "%"+escapeLike(params.DeveloperName)+"%")
+	}
+	if len(params.Statuses) > 0 {
+		query = query.Where("projects.status IN ?", params.Statuses)
+	}
+	// Draft dan review hanya terlihat oleh creator dan co-owner-nya
+	query = query.Where(`(projects.status NOT IN ? OR LOWER(projects.creator_wallet_address) = LOWER(?) OR EXISTS (
+		SELECT 1 FROM project_co_owners co
+		WHERE co.project_id = projects.id AND LOWER(co.wallet_address) = LOWER(?)))`,
+		model.PrivateProjectStatuses, params.ViewerWalletAddress, params.ViewerWalletAddress)
+	return query
+}
+
+// EncodeProjectCursor membuat next_cursor yang menunjuk ke project terakhir di halaman
+func EncodeProjectCursor(sort string, desc bool, last model.Project) (string, error) {
+	var value interface{}
+	switch sort {
+	case "created_at":
+		value = last.CreatedAt
+	case "updated_at":
+		value = last.UpdatedAt
+	case "title":
+		value = last.Title
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(projectCursor{Sort: sort, Desc: desc, Value: raw, ID: last.ID})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+// DecodeProjectCursor mengembalikan nilai sort dan ID project dari cursor.
+// Cursor yang tidak valid, dibuat untuk sort lain, atau dikirim untuk sort tanpa cursor
+// menghasilkan ErrInvalidCursor.
+func DecodeProjectCursor(encoded, sort string, desc bool) (interface{}, uint64, error) {
+	if !ProjectSortHasCursor(sort) {
+		return nil, 0, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+
+	var cursor projectCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+	// Cursor hanya berlaku untuk urutan yang sama dengan saat ia dibuat
+	if cursor.Sort != sort || cursor.Desc != desc {
+		return nil, 0, ErrInvalidCursor
+	}
+
+	var value interface{}
+	switch sort {
+	case "created_at", "updated_at":
+		var t time.Time
+		err = json.Unmarshal(cursor.Value, &t)
+		value = t
+	case "title":
+		var s string
+		err = json.Unmarshal(cursor.Value, &s)
+		value = s
+	}
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+
+	return value, cursor.ID, nil
+}
+
+// escapeLike meng-escape karakter wildcard LIKE agar input user diperlakukan literal
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -1,12 +1,20 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"time"
 
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrVersionMismatch dikembalikan Update dan UpdatePartial ketika versi project di database
+// sudah berbeda dari versi yang dibaca client (optimistic concurrency)
+var ErrVersionMismatch = apperror.PreconditionFailed("Project has been modified since it was last read")
+
 // ProjectRepository menangani operasi database untuk projects
 type ProjectRepository struct {
 	db *gorm.DB
@@ -17,27 +25,39 @@ func NewProjectRepository(db *gorm.DB) *ProjectRepository {
 	return &ProjectRepository{db: db}
 }
 
+// WithContext mengembalikan repository yang menjalankan query dengan ctx, sehingga
+// pembatalan request dan request ID ikut sampai ke query dan log SQL
+func (r *ProjectRepository) WithContext(ctx context.Context) ProjectStore {
+	return &ProjectRepository{db: r.db.WithContext(ctx)}
+}
+
 // GetAll mengambil semua proyek
 func (r *ProjectRepository) GetAll() ([]model.Project, error) {
 	var projects []model.Project
-	result := r.db.Preload("Links").Find(&projects)
-	return projects, result.Error
+	result := r.db.Preload("Links", orderLinks).Find(&projects)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return projects, r.attachDerivedToSlice(projects)
 }
 
 // GetByID mengambil proyek berdasarkan ID
 func (r *ProjectRepository) GetByID(id uint64) (*model.Project, error) {
 	var project model.Project
-	result := r.db.Preload("Links").First(&project, "id = ?", id)
+	result := r.db.Preload("Links", orderLinks).First(&project, "id = ?", id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
-	return &project, result.Error
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &project, r.attachDerived([]*model.Project{&project})
 }
 
 // Create membuat proyek baru
 func (r *ProjectRepository) Create(project *model.Project) error {
 	// create project and its links in a transaction
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(project).Error; err != nil {
 			return err
 		}
@@ -54,105 +74,358 @@ func (r *ProjectRepository) Create(project *model.Project) error {
 		}
 		return nil
 	})
+	if err != nil {
+		return translateError(err)
+	}
+	return r.attachDerived([]*model.Project{project})
 }
 
-// Update memperbarui proyek
+// Update mengganti field proyek dan links-nya jika versi di database masih sama dengan
+// project.Version, lalu menaikkan versinya. Mengembalikan ErrVersionMismatch jika project
+// sudah diubah pihak lain dan apperror.ErrNotFound jika project tidak ada.
 func (r *ProjectRepository) Update(project *model.Project) error {
-	// replace project fields and replace links in a transaction
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Save(project).Error; err != nil {
-			return err
+	// replace project fields and replace links in a transaction.
+	// Creator dan created_at tidak pernah diganti. Status hanya boleh berubah lewat
+	// TransitionStatus, jadi nilainya dibaca ulang dari database bersama field-field tersebut.
+	expected := project.Version
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		project.Version = expected + 1
+		result := tx.Model(project).Where("version = ?", expected).
+			Select("*").Omit("id", "creator_wallet_address", "status", "status_changed_at", "created_at", "deleted_at", clause.Associations).
+			Updates(project)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return staleProjectError(tx, project.ID)
 		}
-		// Replace links: delete existing and insert new ones if provided
-		if err := tx.Where("project_id = ?", project.ID).Delete(&model.ExternalLink{}).Error; err != nil {
+		if err := tx.Model(&model.Project{}).Select("creator_wallet_address", "status", "status_changed_at", "created_at").
+			Where("id = ?", project.ID).Scan(project).Error; err != nil {
 			return err
 		}
-		if len(project.Links) > 0 {
-			for i := range project.Links {
-				// ensure DB will assign the ID for new links
-				project.Links[i].ID = 0
-				project.Links[i].ProjectID = project.ID
-			}
-			if err := tx.Create(&project.Links).Error; err != nil {
-				return err
-			}
+		return replaceLinks(tx, project.ID, project.Links)
+	})
+	if err != nil {
+		project.Version = expected
+		return translateError(err)
+	}
+	return r.attachDerived([]*model.Project{project})
+}
+
+// ProjectChanges adalah perubahan sebagian untuk UpdatePartial
+type ProjectChanges struct {
+	Columns      map[string]interface{} // nama kolom -> nilai baru; nil berarti NULL
+	ReplaceLinks bool                   // ganti semua links dengan Links (kosong berarti hapus semua)
+	Links        []model.ExternalLink
+}
+
+// UpdatePartial menerapkan changes jika versi project masih version, lalu menaikkan versinya.
+// Project dikembalikan dalam keadaan terbaru lengkap dengan links. Mengembalikan nil, nil jika
+// project tidak ada dan ErrVersionMismatch jika versinya berbeda.
+func (r *ProjectRepository) UpdatePartial(id, version uint64, changes ProjectChanges) (*model.Project, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		columns := make(map[string]interface{}, len(changes.Columns)+1)
+		for column, value := range changes.Columns {
+			columns[column] = value
+		}
+		columns["version"] = gorm.Expr("version + 1")
+
+		result := tx.Model(&model.Project{}).Where("id = ? AND version = ?", id, version).Updates(columns)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return staleProjectError(tx, id)
+		}
+		if changes.ReplaceLinks {
+			return replaceLinks(tx, id, changes.Links)
 		}
 		return nil
 	})
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return r.GetByID(id)
+}
+
+// replaceLinks mengganti semua external links project dengan links (urutan position dipertahankan)
+func replaceLinks(tx *gorm.DB, projectID uint64, links []model.ExternalLink) error {
+	if err := tx.Where("project_id = ?", projectID).Delete(&model.ExternalLink{}).Error; err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	for i := range links {
+		// ensure DB will assign the ID for new links
+		links[i].ID = 0
+		links[i].ProjectID = projectID
+	}
+	return tx.Create(&links).Error
+}
+
+// staleProjectError menjelaskan update bersyarat versi yang tidak mengenai baris apa pun:
+// project sudah tidak ada, atau versinya sudah dinaikkan oleh penulis lain
+func staleProjectError(tx *gorm.DB, id uint64) error {
+	var count int64
+	if err := tx.Model(&model.Project{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrProjectNotFound
+	}
+	return ErrVersionMismatch
 }
 
-// UpdatePartial memperbarui sebagian field proyek
-func (r *ProjectRepository) UpdatePartial(id uint64, updates map[string]interface{}) (*model.Project, error) {
+// touchProject menaikkan versi project tanpa mengubah field lain, dipakai ketika data yang
+// ikut membentuk ETag project (misalnya external links) berubah
+func touchProject(tx *gorm.DB, projectID uint64) error {
+	return tx.Model(&model.Project{}).Where("id = ?", projectID).
+		UpdateColumn("version", gorm.Expr("version + 1")).Error
+}
+
+// Delete melakukan soft delete pada proyek (mengisi deleted_at).
+// Comments, links dan ledger tetap ada sampai proyek dipurge (lihat PurgeDeleted).
+func (r *ProjectRepository) Delete(id uint64) error {
+	result := r.db.Delete(&model.Project{}, "id = ?", id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+// GetByIDWithDeleted mengambil proyek berdasarkan ID termasuk yang sudah di-soft delete
+func (r *ProjectRepository) GetByIDWithDeleted(id uint64) (*model.Project, error) {
 	var project model.Project
-	result := r.db.First(&project, "id = ?", id)
+	result := r.db.Unscoped().Preload("Links", orderLinks).First(&project, "id = ?", id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	return &project, r.attachDerived([]*model.Project{&project})
+}
 
-	if err := r.db.Model(&project).Updates(updates).Error; err != nil {
-		return nil, err
+// Restore membatalkan soft delete proyek
+func (r *ProjectRepository) Restore(id uint64) (*model.Project, error) {
+	result := r.db.Unscoped().Model(&model.Project{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return nil, result.Error
 	}
-
-	return &project, nil
+	if result.RowsAffected == 0 {
+		return nil, ErrProjectNotDeleted
+	}
+	return r.GetByID(id)
 }
 
-// Delete menghapus proyek
-func (r *ProjectRepository) Delete(id uint64) error {
-	return r.db.Delete(&model.Project{}, "id = ?", id).Error
+// PurgeDeleted menghapus permanen proyek yang di-soft delete sebelum waktu before.
+// Comments, links, co-owner dan ledger ikut terhapus lewat ON DELETE CASCADE.
+func (r *ProjectRepository) PurgeDeleted(before time.Time) (int64, error) {
+	result := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&model.Project{})
+	return result.RowsAffected, result.Error
 }
 
-// AddInvestor menambahkan wallet address investor ke project
+// AddInvestor menambahkan wallet address investor ke project.
+// Investor manual dicatat di ledger sebagai entri tanpa nominal maupun transaksi.
 func (r *ProjectRepository) AddInvestor(projectID uint64, walletAddress string) error {
 	var project model.Project
 	result := r.db.First(&project, "id = ?", projectID)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return errors.New("project not found")
+		return ErrProjectNotFound
 	}
 	if result.Error != nil {
 		return result.Error
 	}
 
 	// Check if investor already exists
-	for _, addr := range project.InvestorWalletAddresses {
-		if addr == walletAddress {
-			return errors.New("investor already exists")
-		}
+	var count int64
+	if err := r.db.Model(&model.Investment{}).
+		Where("project_id = ? AND LOWER(wallet_address) = LOWER(?)", projectID, walletAddress).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrInvestorExists
 	}
 
-	// Add investor using PostgreSQL array_append
-	return r.db.Model(&project).Update("investor_wallet_addresses",
-		gorm.Expr("array_append(investor_wallet_addresses, ?)", walletAddress)).Error
+	return translateError(r.db.Create(&model.Investment{
+		ProjectID:     projectID,
+		WalletAddress: walletAddress,
+		Kind:          model.InvestmentKindInvestment,
+		Source:        model.InvestmentSourceManual,
+		Amount:        "0",
+		InvestedAt:    time.Now(),
+	}).Error)
 }
 
-// RemoveInvestor menghapus wallet address investor dari project
+// ErrOnChainInvestor dikembalikan RemoveInvestor jika wallet punya entri ledger dari indexer.
+// Entri on-chain tidak boleh dihapus karena checkpoint indexer sudah melewati bloknya.
+var ErrOnChainInvestor = apperror.Conflict("Investor has on-chain investments and cannot be removed")
+
+// RemoveInvestor menghapus entri ledger manual milik wallet address investor
+// dari project. Jika wallet punya entri on-chain, tidak ada yang dihapus dan ErrOnChainInvestor
+// dikembalikan.
 func (r *ProjectRepository) RemoveInvestor(projectID uint64, walletAddress string) error {
-	var project model.Project
-	result := r.db.First(&project, "id = ?", projectID)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return errors.New("project not found")
-	}
-	if result.Error != nil {
-		return result.Error
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var project model.Project
+		result := tx.First(&project, "id = ?", projectID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return ErrProjectNotFound
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		byWallet := tx.Model(&model.Investment{}).
+			Where("project_id = ? AND LOWER(wallet_address) = LOWER(?)", projectID, walletAddress)
 
-	// Remove investor using PostgreSQL array_remove
-	return r.db.Model(&project).Update("investor_wallet_addresses",
-		gorm.Expr("array_remove(investor_wallet_addresses, ?)", walletAddress)).Error
+		var onChain int64
+		if err := byWallet.Session(&gorm.Session{}).Where("source = ?", model.InvestmentSourceIndexed).Count(&onChain).Error; err != nil {
+			return err
+		}
+		if onChain > 0 {
+			return ErrOnChainInvestor
+		}
+
+		return byWallet.Session(&gorm.Session{}).Where("source = ?", model.InvestmentSourceManual).Delete(&model.Investment{}).Error
+	})
 }
 
-// GetInvestors mengambil semua investor wallet addresses untuk project
+// GetInvestors mengambil semua investor wallet addresses (distinct) untuk project dari ledger
 func (r *ProjectRepository) GetInvestors(projectID uint64) ([]string, error) {
 	var project model.Project
 	result := r.db.First(&project, "id = ?", projectID)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return nil, errors.New("project not found")
+		return nil, ErrProjectNotFound
 	}
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	if err := r.attachInvestors([]*model.Project{&project}); err != nil {
+		return nil, err
+	}
 	return project.InvestorWalletAddresses, nil
 }
+
+// attachInvestors mengisi InvestorWalletAddresses setiap project dari ledger investasi,
+// diurutkan berdasarkan investasi pertama setiap wallet
+func (r *ProjectRepository) attachInvestors(projects []*model.Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+
+	var rows []struct {
+		ProjectID     uint64
+		WalletAddress string
+	}
+	err := r.db.Model(&model.Investment{}).
+		Select("project_id, MIN(wallet_address) AS wallet_address").
+		Where("project_id IN ? AND kind = ?", ids, model.InvestmentKindInvestment).
+		Group("project_id, LOWER(wallet_address)").
+		Order("project_id, MIN(invested_at), MIN(id)").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	byProject := make(map[uint64]model.StringArray, len(projects))
+	for _, row := range rows {
+		byProject[row.ProjectID] = append(byProject[row.ProjectID], row.WalletAddress)
+	}
+	for _, p := range projects {
+		p.InvestorWalletAddresses = byProject[p.ID]
+		if p.InvestorWalletAddresses == nil {
+			p.InvestorWalletAddresses = model.StringArray{}
+		}
+	}
+	return nil
+}
+
+// attachDerived mengisi semua field project yang diturunkan dari ledger investasi
+func (r *ProjectRepository) attachDerived(projects []*model.Project) error {
+	if err := r.attachInvestors(projects); err != nil {
+		return err
+	}
+	return r.attachFundingProgress(projects)
+}
+
+// attachDerivedToSlice adalah helper attachDerived untuk slice nilai
+func (r *ProjectRepository) attachDerivedToSlice(projects []model.Project) error {
+	ptrs := make([]*model.Project, len(projects))
+	for i := range projects {
+		ptrs[i] = &projects[i]
+	}
+	return r.attachDerived(ptrs)
+}
+
+// IsCoOwner mengecek apakah wallet adalah co-owner dari project
+func (r *ProjectRepository) IsCoOwner(projectID uint64, walletAddress string) (bool, error) {
+	var count int64
+	result := r.db.Model(&model.ProjectCoOwner{}).
+		Where("project_id = ? AND LOWER(wallet_address) = LOWER(?)", projectID, walletAddress).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+// GetCoOwners mengambil semua co-owner untuk project
+func (r *ProjectRepository) GetCoOwners(projectID uint64) ([]model.ProjectCoOwner, error) {
+	var coOwners []model.ProjectCoOwner
+	result := r.db.Where("project_id = ?", projectID).Order("created_at ASC").Find(&coOwners)
+	return coOwners, result.Error
+}
+
+// AddCoOwner menambahkan co-owner ke project
+func (r *ProjectRepository) AddCoOwner(coOwner *model.ProjectCoOwner) error {
+	exists, err := r.IsCoOwner(coOwner.ProjectID, coOwner.WalletAddress)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCoOwnerExists
+	}
+	return translateError(r.db.Create(coOwner).Error)
+}
+
+// RemoveCoOwner menghapus co-owner dari project
+func (r *ProjectRepository) RemoveCoOwner(projectID uint64, walletAddress string) error {
+	result := r.db.Where("project_id = ? AND LOWER(wallet_address) = LOWER(?)", projectID, walletAddress).
+		Delete(&model.ProjectCoOwner{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCoOwnerNotFound
+	}
+	return nil
+}
+
+// Exists mengecek apakah project dengan ID tersebut ada
+func (r *ProjectRepository) Exists(id uint64) (bool, error) {
+	var count int64
+	result := r.db.Model(&model.Project{}).Where("id = ?", id).Count(&count)
+	return count > 0, result.Error
+}
+
+// ExistsWithDeleted mengecek keberadaan project termasuk yang sudah di-soft delete
+func (r *ProjectRepository) ExistsWithDeleted(id uint64) (bool, error) {
+	var count int64
+	result := r.db.Unscoped().Model(&model.Project{}).Where("id = ?", id).Count(&count)
+	return count > 0, result.Error
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+)
+
+// SearchLanguages memetakan kode bahasa publik ke konfigurasi text search Postgres
+// beserta kolom tsvector yang dipelihara oleh migrasi (lihat migrations/0006_project_search.up.sql)
+var SearchLanguages = map[string]struct {
+	Config string
+	Column string
+}{
+	"en": {Config: "english", Column: "search_vector_en"},
+	"id": {Config: "indonesian", Column: "search_vector_id"},
+}
+
+// ProjectSearchParams berisi parameter pencarian full-text project
+type ProjectSearchParams struct {
+	Query    string
+	Language string // "en", "id", atau kosong untuk keduanya
+	Limit    int
+	Offset   int
+	Statuses []string // kosong berarti semua status
+}
+
+// ProjectSearchHit adalah satu hasil pencarian beserta skor dan cuplikan yang di-highlight
+type ProjectSearchHit struct {
+	Project              model.Project
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+// projectSearchRow adalah hasil mentah query ranking sebelum project dimuat lengkap
+type projectSearchRow struct {
+	ID                   uint64
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+// Search mencari project berdasarkan title, description, developer_name dan genre.
+// Hasil diurutkan berdasarkan relevansi (ts_rank), lalu ID terbaru.
+func (r *ProjectRepository) Search(params ProjectSearchParams) ([]ProjectSearchHit, int64, error) {
+	languages := []string{"en", "id"}
+	if params.Language != "" {
+		if _, ok := SearchLanguages[params.Language]; !ok {
+			return nil, 0, fmt.Errorf("unsupported search language %q", params.Language)
+		}
+		languages = []string{params.Language}
+	}
+	if params.Limit <= 0 || params.Limit > MaxProjectPageSize {
+		params.Limit = DefaultProjectPageSize
+	}
+
+	// Bangun kondisi match dan ekspresi rank untuk setiap bahasa yang dipakai
+	var match, rank string
+	for i, lang := range languages {
+		cfg := SearchLanguages[lang]
+		cond := fmt.Sprintf("%s @@ websearch_to_tsquery('%s', @q)", cfg.Column, cfg.Config)
+		score := fmt.Sprintf("ts_rank(%s, websearch_to_tsquery('%s', @q))", cfg.Column, cfg.Config)
+		if i == 0 {
+			match, rank = cond, score
+		} else {
+			match = match + " OR " + cond
+			rank = fmt.Sprintf("GREATEST(%s, %s)", rank, score)
+		}
+	}
+	headlineCfg := SearchLanguages[languages[0]].Config
+	named := map[string]interface{}{
+		"q":      params.Query,
+		"limit":  params.Limit,
+		"offset": params.Offset,
+	}
+	match = fmt.Sprintf("(%s) AND deleted_at IS NULL", match)
+	if len(params.Statuses) > 0 {
+		match = match + " AND status IN @statuses"
+		named["statuses"] = params.Statuses
+	}
+
+	var total int64
+	countSQL := fmt.Sprintf("SELECT COUNT(*) FROM projects WHERE %s", match)
+	if err := r.db.Raw(countSQL, named).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []ProjectSearchHit{}, 0, nil
+	}
+
+	// ts_headline menandai kata dengan karakter kontrol, bukan <mark>, karena title/description
+	// adalah input user; penanda baru menjadi <mark> setelah teksnya di-escape (lihat escapeHighlight)
+	headlineSel := "StartSel=\"" + highlightStart + "\", StopSel=\"" + highlightStop + "\""
+	headlineOpts := headlineSel + ", MaxFragments=2, MaxWords=30, MinWords=10"
+	searchSQL := fmt.Sprintf(`
+		SELECT id,
+			%[1]s AS rank,
+			ts_headline('%[2]s', translate(title, chr(2) || chr(3), ''), websearch_to_tsquery('%[2]s', @q), '%[5]s, HighlightAll=true') AS title_highlight,
+			ts_headline('%[2]s', translate(COALESCE(description, ''), chr(2) || chr(3), ''), websearch_to_tsquery('%[2]s', @q), '%[3]s') AS description_highlight
+		FROM projects
+		WHERE %[4]s
+		ORDER BY rank DESC, id DESC
+		LIMIT @limit OFFSET @offset`, rank, headlineCfg, headlineOpts, match, headlineSel)
+
+	var rows []projectSearchRow
+	if err := r.db.Raw(searchSQL, named).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint64, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	var projects []model.Project
+	if err := r.db.Preload("Links", orderLinks).Where("id IN ?", ids).Find(&projects).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := r.attachDerivedToSlice(projects); err != nil {
+		return nil, 0, err
+	}
+
+	byID := make(map[uint64]model.Project, len(projects))
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+
+	// Pertahankan urutan ranking dari query pertama
+	hits := make([]ProjectSearchHit, 0, len(rows))
+	for _, row := range rows {
+		project, ok := byID[row.ID]
+		if !ok {
+			continue
+		}
+		hits = append(hits, ProjectSearchHit{
+			Project:              project,
+			Rank:                 row.Rank,
+			TitleHighlight:       escapeHighlight(row.TitleHighlight),
+			DescriptionHighlight: escapeHighlight(row.DescriptionHighlight),
+		})
+	}
+
+	return hits, total, nil
+}
+
+// Penanda highlight dari ts_headline (STX/ETX). Karakter ini dibuang dari teks sebelum
+// ts_headline sehingga hanya bisa berasal dari penanda.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+// escapeHighlight meng-escape HTML pada cuplikan lalu mengganti penanda dengan <mark>,
+// sehingga <mark> adalah satu-satunya markup di highlight
+func escapeHighlight(text string) string {
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(html.EscapeString(text))
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidTransition dikembalikan ketika perpindahan status project tidak diizinkan
+var ErrInvalidTransition = apperror.Conflict("invalid status transition")
+
+// InvalidTransitionError menjelaskan transisi from -> to yang ditolak beserta transisi yang
+// masih diizinkan dari status saat ini; errors.Is(err, ErrInvalidTransition) tetap berlaku
+func InvalidTransitionError(from, to string) *apperror.Error {
+	return apperror.Conflict("Cannot move project from "+from+" to "+to).
+		With("current_status", from).
+		With("allowed_transitions", model.AllowedProjectStatusTransitions(from)).
+		Wrap(ErrInvalidTransition)
+}
+
+// TransitionStatus memindahkan status project ke status to jika transisinya sah
+// menurut model.CanTransitionProjectStatus. Baris project dikunci selama pengecekan
+// agar dua transisi bersamaan tidak saling menimpa. Mengembalikan nil, nil jika project tidak ada
+// dan InvalidTransitionError jika transisinya tidak sah.
+func (r *ProjectRepository) TransitionStatus(id uint64, to string) (*model.Project, error) {
+	var project model.Project
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if !model.CanTransitionProjectStatus(project.Status, to) {
+			return InvalidTransitionError(project.Status, to)
+		}
+
+		now := time.Now()
+		if err := tx.Model(&project).Updates(map[string]interface{}{
+			"status":            to,
+			"status_changed_at": now,
+			"version":           gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Preload("Links", orderLinks).First(&project, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &project, r.attachDerived([]*model.Project{&project})
+}
+
+// SettleEnded memindahkan project live yang deadline-nya sudah lewat ke funded atau failed.
+// Project dianggap funded jika total bersih on-chain (entri indexer) dalam mata uangnya mencapai
+// goal_amount, atau (untuk project tanpa target) jika ada dana yang masuk sama sekali.
+func (r *ProjectRepository) SettleEnded(now time.Time) (int64, error) {
+	result := r.db.Exec(`
+		UPDATE projects p
+		SET status = CASE
+				WHEN raised.amount >= COALESCE(p.goal_amount, 1) THEN ?
+				ELSE ?
+			END,
+			status_changed_at = ?,
+			updated_at = ?,
+			version = p.version + 1
+		FROM (
+			SELECT p2.id, COALESCE(SUM(CASE WHEN i.kind = ? THEN -i.amount ELSE i.amount END), 0) AS amount
+			FROM projects p2
+			LEFT JOIN investments i
+				ON i.project_id = p2.id AND i.source = ? AND i.chain_id = p2.currency_chain_id
+				AND LOWER(i.token_address) = LOWER(p2.currency_token_address)
+			WHERE p2.status = ? AND p2.deleted_at IS NULL AND p2.end_date IS NOT NULL AND p2.end_date <= ?
+			GROUP BY p2.id
+		) AS raised
+		WHERE p.id = raised.id AND p.status = ?`,
+		model.ProjectStatusFunded, model.ProjectStatusFailed, now, now,
+		model.InvestmentKindRefund, model.InvestmentSourceIndexed, model.ProjectStatusLive, now, model.ProjectStatusLive)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+)
+
+// Interface di file ini adalah kontrak yang dipakai handler, router dan worker.
+// Implementasi Postgres ada di package ini; implementasi in-memory untuk test ada di
+// package repository/memory dan harus mengikuti semantik yang sama (nil, nil untuk
+// data yang tidak ada, pesan error yang sama untuk konflik). Handler memanggil
+// WithContext(c.UserContext()) agar query membawa context request.
+
+// ProjectStore adalah operasi penyimpanan project beserta investor, co-owner dan status-nya
+type ProjectStore interface {
+	WithContext(ctx context.Context) ProjectStore
+
+	GetAll() ([]model.Project, error)
+	GetByID(id uint64) (*model.Project, error)
+	GetByIDWithDeleted(id uint64) (*model.Project, error)
+	List(params ProjectListParams) (*ProjectPage, error)
+	Search(params ProjectSearchParams) ([]ProjectSearchHit, int64, error)
+	Create(project *model.Project) error
+	Update(project *model.Project) error
+	UpdatePartial(id, version uint64, changes ProjectChanges) (*model.Project, error)
+	Delete(id uint64) error
+	Restore(id uint64) (*model.Project, error)
+	PurgeDeleted(before time.Time) (int64, error)
+	TransitionStatus(id uint64, to string) (*model.Project, error)
+	SettleEnded(now time.Time) (int64, error)
+	Exists(id uint64) (bool, error)
+	ExistsWithDeleted(id uint64) (bool, error)
+
+	AddInvestor(projectID uint64, walletAddress string) error
+	RemoveInvestor(projectID uint64, walletAddress string) error
+	GetInvestors(projectID uint64) ([]string, error)
+
+	IsCoOwner(projectID uint64, walletAddress string) (bool, error)
+	GetCoOwners(projectID uint64) ([]model.ProjectCoOwner, error)
+	AddCoOwner(coOwner *model.ProjectCoOwner) error
+	RemoveCoOwner(projectID uint64, walletAddress string) error
+}
+
+// CommentStore adalah operasi penyimpanan komentar beserta revisi, reaksi dan laporannya
+type CommentStore interface {
+	WithContext(ctx context.Context) CommentStore
+
+	GetByProjectID(projectID uint64, params CommentListParams) ([]model.Comment, error)
+	GetTree(params CommentTreeParams) (*CommentTreePage, error)
+	GetByID(id uint64) (*model.Comment, error)
+	GetReplies(parentID uint64) ([]model.Comment, error)
+	Depth(id uint64) (int, error)
+	Create(comment *model.Comment) error
+	Update(comment *model.Comment) error
+	Edit(comment *model.Comment, content, signature string) error
+	Delete(id uint64) (bool, error)
+	GetRevisions(commentID uint64) ([]model.CommentRevision, error)
+
+	AddReaction(reaction *model.CommentReaction) (bool, error)
+	RemoveReaction(commentID uint64, walletAddress, reactionType string) (bool, error)
+	AttachReactions(comments []*model.Comment, viewer string) error
+
+	Report(report *model.CommentReport) error
+	ModerationQueue(params ModerationQueueParams) ([]model.ReportedComment, int64, error)
+	SetHidden(commentID uint64, hidden bool, moderator string) error
+	ResolveReports(commentID uint64, resolution, moderator string) error
+}
+
+// ExternalLinkStore adalah operasi penyimpanan external links project
+type ExternalLinkStore interface {
+	WithContext(ctx context.Context) ExternalLinkStore
+
+	GetByProjectID(projectID uint64) ([]model.ExternalLink, error)
+	GetByID(id uint64) (*model.ExternalLink, error)
+	NextPosition(projectID uint64) (int, error)
+	Create(link *model.ExternalLink) error
+	Update(link *model.ExternalLink) error
+	Delete(id uint64) error
+	DeleteByProjectID(projectID uint64) error
+}
+
+// InvestmentStore adalah operasi penyimpanan ledger investasi
+type InvestmentStore interface {
+	WithContext(ctx context.Context) InvestmentStore
+
+	Create(investment *model.Investment) error
+	ListByProject(projectID uint64, params InvestmentListParams) ([]model.Investment, int64, error)
+	Summary(projectID uint64) (*model.InvestmentSummary, error)
+}
+
+// UserProfileStore adalah operasi penyimpanan profil user
+type UserProfileStore interface {
+	WithContext(ctx context.Context) UserProfileStore
+
+	GetByWalletAddress(walletAddress string) (*model.UserProfile, error)
+	Create(profile *model.UserProfile) error
+	Update(profile *model.UserProfile) error
+	Upsert(profile *model.UserProfile) error
+	Delete(walletAddress string) error
+}
+
+// AuthNonceStore adalah operasi penyimpanan nonce SIWE
+type AuthNonceStore interface {
+	WithContext(ctx context.Context) AuthNonceStore
+
+	Create(nonce *model.AuthNonce) error
+	Consume(nonce string) (bool, error)
+}
+
+// IndexerCheckpointStore adalah operasi penyimpanan checkpoint indexer on-chain
+type IndexerCheckpointStore interface {
+	GetByName(name string) (*model.IndexerCheckpoint, error)
+	SaveBatch(checkpoint *model.IndexerCheckpoint, investments []model.Investment, pending []model.IndexerPendingEvent) (int64, error)
+	PromotePending(chainID int64) (int64, error)
+}
+
+// Pastikan implementasi Postgres memenuhi semua interface
+var (
+	_ ProjectStore           = (*ProjectRepository)(nil)
+	_ CommentStore           = (*CommentRepository)(nil)
+	_ ExternalLinkStore      = (*ExternalLinkRepository)(nil)
+	_ InvestmentStore        = (*InvestmentRepository)(nil)
+	_ UserProfileStore       = (*UserProfileRepository)(nil)
+	_ AuthNonceStore         = (*AuthNonceRepository)(nil)
+	_ IndexerCheckpointStore = (*IndexerCheckpointRepository)(nil)
+)
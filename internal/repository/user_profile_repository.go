@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 
 	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
@@ -18,6 +19,12 @@ func NewUserProfileRepository(db *gorm.DB) *UserProfileRepository {
 	return &UserProfileRepository{db: db}
 }
 
+// WithContext mengembalikan repository yang menjalankan query dengan ctx, sehingga
+// pembatalan request dan request ID ikut sampai ke query dan log SQL
+func (r *UserProfileRepository) WithContext(ctx context.Context) UserProfileStore {
+	return &UserProfileRepository{db: r.db.WithContext(ctx)}
+}
+
 // GetByWalletAddress mengambil profil berdasarkan wallet address
 func (r *UserProfileRepository) GetByWalletAddress(walletAddress string) (*model.UserProfile, error) {
 	var profile model.UserProfile
@@ -30,20 +37,23 @@ func (r *UserProfileRepository) GetByWalletAddress(walletAddress string) (*model
 
 // Create membuat profil baru
 func (r *UserProfileRepository) Create(profile *model.UserProfile) error {
-	return r.db.Create(profile).Error
+	return translateError(r.db.Create(profile).Error)
 }
 
 // Update memperbarui profil
 func (r *UserProfileRepository) Update(profile *model.UserProfile) error {
-	return r.db.Save(profile).Error
+	return translateError(r.db.Save(profile).Error)
 }
 
-// Upsert membuat atau memperbarui profil (create or update)
+// Upsert membuat atau memperbarui profil (create or update). kyc_status profil yang sudah ada
+// tidak diubah; profil baru mendapat model.KYCStatusUnverified. profile diisi ulang dari baris
+// yang tersimpan sehingga kyc_status dan created_at sesuai database.
 func (r *UserProfileRepository) Upsert(profile *model.UserProfile) error {
-	return r.db.Clauses(clause.OnConflict{
+	profile.KYCStatus = model.KYCStatusUnverified
+	return translateError(r.db.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "wallet_address"}},
-		DoUpdates: clause.AssignmentColumns([]string{"username", "email", "profile_image_url", "kyc_status", "updated_at"}),
-	}).Create(profile).Error
+		DoUpdates: clause.AssignmentColumns([]string{"username", "email", "profile_image_url", "updated_at"}),
+	}, clause.Returning{}).Create(profile).Error)
 }
 
 // Delete menghapus profil
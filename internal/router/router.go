@@ -3,41 +3,99 @@ package router
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/auth"
 	"github.com/kevinchr/web3-crowdfunding-api/internal/handler"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository"
 )
 
 // SetupRoutes mengatur semua rute API
-func SetupRoutes(app *fiber.App, projectHandler *handler.ProjectHandler, profileHandler *handler.UserProfileHandler, commentHandler *handler.CommentHandler) {
+func SetupRoutes(app *fiber.App, tokens *auth.TokenManager, admins auth.AdminSet, projectRepo repository.ProjectStore, authHandler *handler.AuthHandler, projectHandler *handler.ProjectHandler, investmentHandler *handler.InvestmentHandler, profileHandler *handler.UserProfileHandler, commentHandler *handler.CommentHandler, linkHandler *handler.ExternalLinkHandler) {
 	// Swagger documentation endpoint
 	app.Get("/docs/*", swagger.HandlerDefault)
 
 	// API v1 group
 	api := app.Group("/api/v1")
 
+	// Lampirkan wallet dari session token (jika ada) ke setiap request
+	api.Use(auth.Middleware(tokens))
+	requireWallet := auth.RequireWallet()
+	requireOwner := auth.RequireProjectOwner(projectRepo)
+	requireCreator := auth.RequireProjectCreator(projectRepo)
+	requireOwnerOrAdmin := auth.RequireProjectOwnerOrAdmin(projectRepo, admins)
+	requireCreatorOrAdmin := auth.RequireProjectCreatorOrAdmin(projectRepo, admins)
+	requireAdmin := auth.RequireAdmin(admins)
+	requireDeletedOwnerOrAdmin := auth.RequireDeletedProjectOwnerOrAdmin(projectRepo, admins)
+	requireVisible := auth.RequireVisibleProject(projectRepo) // draft/review hanya untuk pemiliknya
+
+	// Routes untuk Sign-In With Ethereum
+	authRoutes := api.Group("/auth")
+	authRoutes.Get("/nonce", authHandler.GetNonce)
+	authRoutes.Post("/verify", authHandler.Verify)
+	authRoutes.Get("/me", requireWallet, authHandler.Me)
+
 	// Routes untuk Projects
 	projects := api.Group("/projects")
 	projects.Get("/", projectHandler.GetAllProjects)
+	projects.Get("/search", projectHandler.SearchProjects) // harus sebelum "/:id"
 	projects.Get("/:id", projectHandler.GetProjectByID)
-	projects.Post("/", projectHandler.CreateProject)
-	projects.Patch("/:id", projectHandler.UpdateProject)
-	projects.Put("/:id", projectHandler.ReplaceProject)
+	projects.Post("/", requireWallet, projectHandler.CreateProject)
+	projects.Patch("/:id", requireWallet, requireOwner, projectHandler.UpdateProject)
+	projects.Put("/:id", requireWallet, requireOwner, projectHandler.ReplaceProject)
+	projects.Delete("/:id", requireWallet, requireOwnerOrAdmin, projectHandler.DeleteProject)
+	projects.Post("/:id/restore", requireWallet, requireDeletedOwnerOrAdmin, projectHandler.RestoreProject)
+
+	// Routes untuk lifecycle project (draft -> review -> live -> ... -> archived)
+	projects.Post("/:id/submit", requireWallet, requireOwner, projectHandler.SubmitProject)
+	projects.Post("/:id/publish", requireWallet, requireAdmin, projectHandler.PublishProject) // review disetujui admin, bukan pemilik
+	projects.Post("/:id/archive", requireWallet, requireOwner, projectHandler.ArchiveProject)
+
+	// Routes untuk pemilik project (creator + co-owner)
+	projects.Get("/:id/owners", requireVisible, projectHandler.GetCoOwners)
+	projects.Post("/:id/owners", requireWallet, requireCreator, projectHandler.AddCoOwner)
+	projects.Delete("/:id/owners/:walletAddress", requireWallet, requireCreator, projectHandler.RemoveCoOwner)
 
 	// Routes untuk Investors (nested under projects)
-	projects.Get("/:id/investors", projectHandler.GetInvestors)
-	projects.Post("/:id/investors", projectHandler.AddInvestor)
-	projects.Delete("/:id/investors/:walletAddress", projectHandler.RemoveInvestor)
+	projects.Get("/:id/investors", requireVisible, projectHandler.GetInvestors)
+	projects.Post("/:id/investors", requireWallet, requireOwner, projectHandler.AddInvestor)
+	projects.Delete("/:id/investors/:walletAddress", requireWallet, requireOwner, projectHandler.RemoveInvestor)
+
+	// Routes untuk ledger investasi
+	projects.Get("/:id/investments", requireVisible, investmentHandler.GetInvestments)
+	projects.Get("/:id/investments/summary", requireVisible, investmentHandler.GetInvestmentSummary)
+	projects.Post("/:id/investments", requireWallet, requireOwner, investmentHandler.CreateInvestment)
 
 	// Routes untuk Comments (nested under projects)
-	projects.Get("/:id/comments", commentHandler.GetCommentsByProjectID)
-	projects.Post("/:id/comments", commentHandler.CreateComment)
+	projects.Get("/:id/comments", requireVisible, commentHandler.GetCommentsByProjectID)
+	projects.Post("/:id/comments", requireWallet, requireVisible, commentHandler.CreateComment)
+	projects.Get("/:id/comments/:commentId/verification", requireVisible, commentHandler.VerifyComment)
+	projects.Get("/:id/comments/:commentId/history", requireVisible, commentHandler.GetCommentHistory)
+	projects.Patch("/:id/comments/:commentId", requireWallet, requireVisible, commentHandler.UpdateComment)
+	projects.Delete("/:id/comments/:commentId", requireWallet, requireVisible, commentHandler.DeleteComment)
+	projects.Put("/:id/comments/:commentId/reactions/:type", requireWallet, requireVisible, commentHandler.AddReaction)
+	projects.Delete("/:id/comments/:commentId/reactions/:type", requireWallet, requireVisible, commentHandler.RemoveReaction)
+	projects.Post("/:id/comments/:commentId/reports", requireWallet, requireVisible, commentHandler.ReportComment)
+
+	// Routes untuk moderasi komentar (creator project + admin)
+	projects.Get("/:id/moderation/comments", requireWallet, requireCreatorOrAdmin, commentHandler.GetModerationQueue)
+	projects.Post("/:id/moderation/comments/:commentId/hide", requireWallet, requireCreatorOrAdmin, commentHandler.HideComment)
+	projects.Post("/:id/moderation/comments/:commentId/unhide", requireWallet, requireCreatorOrAdmin, commentHandler.UnhideComment)
+	projects.Post("/:id/moderation/comments/:commentId/dismiss", requireWallet, requireCreatorOrAdmin, commentHandler.DismissCommentReports)
+	projects.Delete("/:id/moderation/comments/:commentId", requireWallet, requireCreatorOrAdmin, commentHandler.ModeratorDeleteComment)
 
 	// Routes untuk External Links (nested under projects)
-	// External links are now handled as part of project payload (links field)
+	projects.Get("/:id/links", requireVisible, linkHandler.GetLinksByProjectID)
+	projects.Post("/:id/links", requireWallet, requireOwner, linkHandler.CreateLink)
+	projects.Put("/:id/links/:linkId", requireWallet, requireOwner, linkHandler.UpdateLink)
+	projects.Delete("/:id/links/:linkId", requireWallet, requireOwner, linkHandler.DeleteLink)
+
+	// Antrian moderasi komentar lintas project (admin saja)
+	moderation := api.Group("/moderation")
+	moderation.Get("/comments", requireWallet, requireAdmin, commentHandler.GetModerationQueue)
 
 	// Routes untuk User Profiles
 	profiles := api.Group("/profiles")
 	profiles.Get("/:walletAddress", profileHandler.GetProfileByWalletAddress)
-	profiles.Put("/:walletAddress", profileHandler.UpsertProfile)
+	profiles.Put("/:walletAddress", requireWallet, profileHandler.UpsertProfile)
 
 	// Health check endpoint
 	// @Summary      Health check
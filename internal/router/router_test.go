@@ -0,0 +1,755 @@
+package router
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/auth"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/handler"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/metrics"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/model"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/problem"
+	"github.com/kevinchr/web3-crowdfunding-api/internal/repository/memory"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const (
+	testInvestor = "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"
+	testDomain   = "localhost:3000"
+)
+
+// testWallet adalah wallet uji beserta private key untuk menandatangani komentar
+type testWallet struct {
+	key     *secp256k1.PrivateKey
+	address string
+}
+
+func newTestWallet(seed byte) testWallet {
+	key := secp256k1.PrivKeyFromBytes(bytes.Repeat([]byte{seed}, 32))
+	addr := auth.Keccak256(key.PubKey().SerializeUncompressed()[1:])[12:]
+	return testWallet{key: key, address: auth.ChecksumAddress("0x" + hex.EncodeToString(addr))}
+}
+
+// sign membuat signature personal_sign (r || s || v) seperti yang dihasilkan wallet
+func (w testWallet) sign(message string) string {
+	compact := ecdsa.SignCompact(w.key, auth.HashPersonalMessage([]byte(message)), false)
+	sig := append(append([]byte{}, compact[1:]...), compact[0])
+	return "0x" + hex.EncodeToString(sig)
+}
+
+// testServer merangkai router lengkap di atas memory store dengan konfigurasi Fiber default
+// (tanpa Immutable), sama seperti cmd/main kecuali middleware observability
+type testServer struct {
+	app    *fiber.App
+	tokens *auth.TokenManager
+	admin  testWallet // satu-satunya wallet di ADMIN_WALLETS
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	db := memory.NewDB()
+	projectRepo := memory.NewProjectRepository(db)
+	tokens := auth.NewTokenManager([]byte("router-test-secret"), time.Hour)
+	admin := newTestWallet(9)
+	admins := auth.NewAdminSet([]string{admin.address})
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	SetupRoutes(app, tokens, admins, projectRepo,
+		handler.NewAuthHandler(memory.NewAuthNonceRepository(db), tokens, auth.SIWEPolicy{Domain: testDomain, ChainIDs: []int64{1}}, time.Minute),
+		handler.NewProjectHandler(projectRepo),
+		handler.NewInvestmentHandler(memory.NewInvestmentRepository(db), projectRepo),
+		handler.NewUserProfileHandler(memory.NewUserProfileRepository(db)),
+		handler.NewCommentHandler(memory.NewCommentRepository(db), projectRepo, admins),
+		handler.NewExternalLinkHandler(memory.NewExternalLinkRepository(db), projectRepo),
+	)
+	return &testServer{app: app, tokens: tokens, admin: admin}
+}
+
+// testRequest adalah satu request ke testServer; wallet kosong berarti tanpa session token
+type testRequest struct {
+	method      string
+	path        string
+	wallet      string
+	body        interface{}
+	contentType string
+	headers     map[string]string
+}
+
+func (s *testServer) do(t *testing.T, r testRequest) *http.Response {
+	t.Helper()
+	var body io.Reader
+	if r.body != nil {
+		raw, err := json.Marshal(r.body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req := httptest.NewRequest(r.method, r.path, body)
+	if r.body != nil {
+		contentType := r.contentType
+		if contentType == "" {
+			contentType = fiber.MIMEApplicationJSON
+		}
+		req.Header.Set(fiber.HeaderContentType, contentType)
+	}
+	if r.wallet != "" {
+		token, _, err := s.tokens.Issue(r.wallet, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+	for key, value := range r.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// expect menjalankan request, mengecek status dan men-decode body JSON ke out (jika tidak nil)
+func (s *testServer) expect(t *testing.T, r testRequest, status int, out interface{}) *http.Response {
+	t.Helper()
+	resp := s.do(t, r)
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != status {
+		t.Fatalf("%s %s: status = %d, want %d; body = %s", r.method, r.path, resp.StatusCode, status, raw)
+	}
+	if out != nil {
+		if err := json.Unmarshal(raw, out); err != nil {
+			t.Fatalf("%s %s: decode body %s: %v", r.method, r.path, raw, err)
+		}
+	}
+	return resp
+}
+
+func (s *testServer) createProject(t *testing.T, owner testWallet, title string) model.Project {
+	t.Helper()
+	var project model.Project
+	s.expect(t, testRequest{method: fiber.MethodPost, path: "/api/v1/projects", wallet: owner.address, body: map[string]interface{}{
+		"title": title,
+		"genre": "RPG",
+	}}, fiber.StatusCreated, &project)
+	return project
+}
+
+// createLiveProject membuat project lalu membawanya lewat review sampai live (disetujui admin)
+func (s *testServer) createLiveProject(t *testing.T, owner testWallet, title string) model.Project {
+	t.Helper()
+	var project model.Project
+	s.expect(t, testRequest{method: fiber.MethodPost, path: "/api/v1/projects", wallet: owner.address, body: map[string]interface{}{
+		"title":       title,
+		"goal_amount": "1000",
+		"end_date":    time.Now().Add(30 * 24 * time.Hour),
+	}}, fiber.StatusCreated, &project)
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/submit"), wallet: owner.address}, fiber.StatusOK, nil)
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/publish"), wallet: s.admin.address}, fiber.StatusOK, &project)
+	return project
+}
+
+// postComment membuat komentar bertanda tangan author; parent nil untuk komentar top-level
+func (s *testServer) postComment(t *testing.T, projectID uint64, author testWallet, parent *uint64, content string) model.Comment {
+	t.Helper()
+	var created model.Comment
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(projectID, "/comments"), wallet: author.address, body: model.CommentCreate{
+		AuthorWalletAddress: author.address, Content: content, ParentCommentID: parent,
+		Signature: author.sign(auth.CommentSigningMessage(projectID, parent, content)),
+	}}, fiber.StatusCreated, &created)
+	return created
+}
+
+func projectPath(id uint64, suffix string) string {
+	return "/api/v1/projects/" + strconv.FormatUint(id, 10) + suffix
+}
+
+func TestProjectCRUD(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	other := newTestWallet(2)
+
+	created := s.createProject(t, owner, "My Awesome Game")
+	if created.CreatorWalletAddress != owner.address || created.Status != model.ProjectStatusDraft || created.Version != 1 {
+		t.Fatalf("created project = %+v", created)
+	}
+	path := projectPath(created.ID, "")
+
+	// Draft hanya terlihat oleh pemiliknya
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path}, fiber.StatusNotFound, nil)
+	var fetched model.Project
+	resp := s.expect(t, testRequest{method: fiber.MethodGet, path: path, wallet: owner.address}, fiber.StatusOK, &fetched)
+	if fetched.Title != "My Awesome Game" || fetched.Genre != "RPG" {
+		t.Fatalf("fetched project = %+v", fetched)
+	}
+	etag := resp.Header.Get(fiber.HeaderETag)
+	if etag == "" {
+		t.Fatal("GET did not return an ETag")
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path, wallet: owner.address,
+		headers: map[string]string{fiber.HeaderIfNoneMatch: etag}}, fiber.StatusNotModified, nil)
+
+	// PATCH wajib If-Match dan hanya boleh oleh pemilik
+	patch := map[string]interface{}{"title": "Renamed"}
+	s.expect(t, testRequest{method: fiber.MethodPatch, path: path, wallet: other.address, body: patch,
+		contentType: "application/merge-patch+json", headers: map[string]string{fiber.HeaderIfMatch: `"1"`}}, fiber.StatusForbidden, nil)
+	s.expect(t, testRequest{method: fiber.MethodPatch, path: path, wallet: owner.address, body: patch,
+		contentType: "application/merge-patch+json"}, fiber.StatusPreconditionRequired, nil)
+	var patched model.Project
+	s.expect(t, testRequest{method: fiber.MethodPatch, path: path, wallet: owner.address, body: patch,
+		contentType: "application/merge-patch+json", headers: map[string]string{fiber.HeaderIfMatch: `"1"`}}, fiber.StatusOK, &patched)
+	if patched.Title != "Renamed" || patched.Genre != "RPG" || patched.Version != 2 {
+		t.Fatalf("patched project = %+v", patched)
+	}
+
+	// PUT dengan ETag lama ditolak, dengan ETag terbaru mengganti seluruh field
+	replacement := map[string]interface{}{"title": "Replaced", "description": "Fully replaced"}
+	var stale map[string]interface{}
+	s.expect(t, testRequest{method: fiber.MethodPut, path: path, wallet: owner.address, body: replacement,
+		headers: map[string]string{fiber.HeaderIfMatch: `"1"`}}, fiber.StatusPreconditionFailed, &stale)
+	if stale["current_version"] != float64(2) {
+		t.Fatalf("412 current_version = %v, want 2", stale["current_version"])
+	}
+	var replaced model.Project
+	s.expect(t, testRequest{method: fiber.MethodPut, path: path, wallet: owner.address, body: replacement,
+		headers: map[string]string{fiber.HeaderIfMatch: `"2"`}}, fiber.StatusOK, &replaced)
+	if replaced.Title != "Replaced" || replaced.Genre != "" || replaced.CreatorWalletAddress != owner.address {
+		t.Fatalf("replaced project = %+v", replaced)
+	}
+
+	s.expect(t, testRequest{method: fiber.MethodDelete, path: path, wallet: other.address}, fiber.StatusForbidden, nil)
+	s.expect(t, testRequest{method: fiber.MethodDelete, path: path, wallet: owner.address}, fiber.StatusOK, nil)
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path, wallet: owner.address}, fiber.StatusNotFound, nil)
+}
+
+func TestProjectInvestors(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	project := s.createProject(t, owner, "Investable")
+	path := projectPath(project.ID, "/investors")
+
+	add := testRequest{method: fiber.MethodPost, path: path, wallet: owner.address, body: model.AddInvestorRequest{WalletAddress: testInvestor}}
+	s.expect(t, add, fiber.StatusOK, nil)
+	s.expect(t, add, fiber.StatusConflict, nil)
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address,
+		body: model.AddInvestorRequest{WalletAddress: "not-a-wallet"}}, fiber.StatusBadRequest, nil)
+
+	var list struct {
+		Investors []string `json:"investors"`
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path, wallet: owner.address}, fiber.StatusOK, &list)
+	if len(list.Investors) != 1 || !auth.SameAddress(list.Investors[0], testInvestor) {
+		t.Fatalf("investors = %v, want [%s]", list.Investors, testInvestor)
+	}
+
+	s.expect(t, testRequest{method: fiber.MethodDelete, path: path + "/" + testInvestor, wallet: owner.address}, fiber.StatusOK, nil)
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path, wallet: owner.address}, fiber.StatusOK, &list)
+	if len(list.Investors) != 0 {
+		t.Fatalf("investors after remove = %v, want none", list.Investors)
+	}
+}
+
+func TestProjectComments(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	other := newTestWallet(2)
+	project := s.createProject(t, owner, "Commented")
+	path := projectPath(project.ID, "/comments")
+
+	content := "This project looks amazing!"
+	signature := owner.sign(auth.CommentSigningMessage(project.ID, nil, content))
+
+	// Author harus wallet yang login, dan signature harus dibuat oleh author
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address, body: model.CommentCreate{
+		AuthorWalletAddress: other.address, Content: content, Signature: signature,
+	}}, fiber.StatusForbidden, nil)
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address, body: model.CommentCreate{
+		AuthorWalletAddress: owner.address, Content: content + " (tampered)", Signature: signature,
+	}}, fiber.StatusUnauthorized, nil)
+
+	var created model.Comment
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address, body: model.CommentCreate{
+		AuthorWalletAddress: owner.address, Content: content, Signature: signature,
+	}}, fiber.StatusCreated, &created)
+
+	// Request lain memakai ulang buffer Fiber; data yang tersimpan tidak boleh ikut berubah
+	s.createProject(t, other, "Another project with a longer title")
+
+	var comments []model.Comment
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path, wallet: owner.address}, fiber.StatusOK, &comments)
+	if len(comments) != 1 {
+		t.Fatalf("comments = %+v, want 1", comments)
+	}
+	got := comments[0]
+	if got.ID != created.ID || got.Content != content || got.Signature != signature || !auth.SameAddress(got.AuthorWalletAddress, owner.address) {
+		t.Fatalf("comment = %+v", got)
+	}
+	if err := auth.VerifyCommentSignature(got.ProjectID, got.ParentCommentID, got.Content, got.AuthorWalletAddress, got.Signature); err != nil {
+		t.Fatalf("stored comment no longer verifies: %v", err)
+	}
+}
+
+func TestUserProfiles(t *testing.T) {
+	s := newTestServer(t)
+	alice := newTestWallet(1)
+	bob := newTestWallet(2)
+
+	upsert := func(w testWallet, body map[string]interface{}, status int, out interface{}) {
+		t.Helper()
+		s.expect(t, testRequest{method: fiber.MethodPut, path: "/api/v1/profiles/" + w.address, wallet: w.address, body: body}, status, out)
+	}
+
+	// kyc_status dimiliki server dan diabaikan jika dikirim client
+	var profile model.UserProfile
+	upsert(alice, map[string]interface{}{"username": "alice", "email": "alice@example.com", "kyc_status": "verified"}, fiber.StatusOK, &profile)
+	if profile.WalletAddress != alice.address || profile.KYCStatus != model.KYCStatusUnverified {
+		t.Fatalf("profile = %+v", profile)
+	}
+
+	s.expect(t, testRequest{method: fiber.MethodPut, path: "/api/v1/profiles/" + alice.address, wallet: bob.address,
+		body: map[string]interface{}{"username": "mallory", "email": "mallory@example.com"}}, fiber.StatusForbidden, nil)
+
+	var conflict struct {
+		Detail string `json:"detail"`
+		Errors []struct {
+			Field string `json:"field"`
+		} `json:"errors"`
+	}
+	upsert(bob, map[string]interface{}{"username": "alice", "email": "bob@example.com"}, fiber.StatusConflict, &conflict)
+	if conflict.Detail != "Username already exists" || len(conflict.Errors) != 1 || conflict.Errors[0].Field != "username" {
+		t.Fatalf("conflict = %+v", conflict)
+	}
+	upsert(bob, map[string]interface{}{"username": "bob", "email": "bob@example.com"}, fiber.StatusOK, nil)
+
+	// Wallet address dari path param harus tetap utuh setelah request lain memakai ulang buffer
+	var fetched model.UserProfile
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/profiles/" + alice.address}, fiber.StatusOK, &fetched)
+	if fetched.WalletAddress != alice.address || fetched.Username != "alice" || fetched.Email != "alice@example.com" {
+		t.Fatalf("fetched profile = %+v", fetched)
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/profiles/" + newTestWallet(3).address}, fiber.StatusNotFound, nil)
+}
+
+func TestManualInvestments(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	project := s.createProject(t, owner, "Manually funded")
+	path := projectPath(project.ID, "/investments")
+
+	// Field on-chain hanya boleh diisi indexer
+	var rejected struct {
+		Errors []struct {
+			Field string `json:"field"`
+		} `json:"errors"`
+	}
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address, body: map[string]interface{}{
+		"wallet_address": testInvestor,
+		"amount":         "1000",
+		"chain_id":       1,
+		"tx_hash":        "0x" + strings.Repeat("a", 64),
+	}}, fiber.StatusBadRequest, &rejected)
+	if len(rejected.Errors) != 2 || rejected.Errors[0].Field != "chain_id" || rejected.Errors[1].Field != "tx_hash" {
+		t.Fatalf("errors = %+v, want chain_id and tx_hash", rejected.Errors)
+	}
+
+	recorded := testutil.ToFloat64(metrics.InvestmentsRecorded.WithLabelValues(model.InvestmentSourceManual))
+	var created model.Investment
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address, body: map[string]interface{}{
+		"wallet_address": testInvestor,
+		"amount":         "1000",
+	}}, fiber.StatusCreated, &created)
+	if created.Source != model.InvestmentSourceManual || created.TxHash != nil {
+		t.Fatalf("created investment = %+v", created)
+	}
+	if got := testutil.ToFloat64(metrics.InvestmentsRecorded.WithLabelValues(model.InvestmentSourceManual)); got != recorded+1 {
+		t.Fatalf("investments_recorded_total{source=manual} = %v, want %v", got, recorded+1)
+	}
+
+	// Entri manual tampil sebagai investor tetapi tidak dihitung ke funding_progress
+	var fetched model.Project
+	s.expect(t, testRequest{method: fiber.MethodGet, path: projectPath(project.ID, ""), wallet: owner.address}, fiber.StatusOK, &fetched)
+	if len(fetched.InvestorWalletAddresses) != 1 || fetched.FundingProgress == nil || fetched.FundingProgress.Raised != "0" {
+		t.Fatalf("project investors = %v, funding = %+v", fetched.InvestorWalletAddresses, fetched.FundingProgress)
+	}
+}
+
+func TestProjectLifecycle(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	var project model.Project
+	s.expect(t, testRequest{method: fiber.MethodPost, path: "/api/v1/projects", wallet: owner.address, body: map[string]interface{}{
+		"title":       "Launching soon",
+		"goal_amount": "1000",
+		"end_date":    time.Now().Add(30 * 24 * time.Hour),
+	}}, fiber.StatusCreated, &project)
+
+	// Publish sebelum review adalah transisi yang tidak sah
+	var conflict map[string]interface{}
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/publish"), wallet: s.admin.address}, fiber.StatusConflict, &conflict)
+	if conflict["current_status"] != model.ProjectStatusDraft {
+		t.Fatalf("409 current_status = %v, want draft", conflict["current_status"])
+	}
+
+	var submitted model.Project
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/submit"), wallet: owner.address}, fiber.StatusOK, &submitted)
+	if submitted.Status != model.ProjectStatusReview {
+		t.Fatalf("status after submit = %s, want review", submitted.Status)
+	}
+
+	// Review hanya bisa disetujui admin; pemilik project tidak bisa mem-publish sendiri
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/publish"), wallet: owner.address}, fiber.StatusForbidden, nil)
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/publish")}, fiber.StatusUnauthorized, nil)
+	s.expect(t, testRequest{method: fiber.MethodGet, path: projectPath(project.ID, "")}, fiber.StatusNotFound, nil)
+
+	var published model.Project
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/publish"), wallet: s.admin.address}, fiber.StatusOK, &published)
+	if published.Status != model.ProjectStatusLive {
+		t.Fatalf("status after publish = %s, want live", published.Status)
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: projectPath(project.ID, "")}, fiber.StatusOK, nil)
+}
+
+func TestCommentEditRequiresVisibleProject(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	coOwner := newTestWallet(2)
+	project := s.createProject(t, owner, "Private draft")
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/owners"), wallet: owner.address,
+		body: model.CoOwnerRequest{WalletAddress: coOwner.address}}, fiber.StatusCreated, nil)
+
+	comment := func(author testWallet, content string) model.Comment {
+		t.Helper()
+		var created model.Comment
+		s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/comments"), wallet: author.address, body: model.CommentCreate{
+			AuthorWalletAddress: author.address, Content: content, Signature: author.sign(auth.CommentSigningMessage(project.ID, nil, content)),
+		}}, fiber.StatusCreated, &created)
+		return created
+	}
+	edit := func(author testWallet, c model.Comment) testRequest {
+		content := c.Content + " (edited)"
+		return testRequest{method: fiber.MethodPatch, path: projectPath(project.ID, "/comments/"+strconv.FormatUint(c.ID, 10)), wallet: author.address,
+			body: model.CommentUpdate{Content: content, Signature: author.sign(auth.CommentSigningMessage(project.ID, nil, content))}}
+	}
+	remove := func(author testWallet, c model.Comment) testRequest {
+		return testRequest{method: fiber.MethodDelete, path: projectPath(project.ID, "/comments/"+strconv.FormatUint(c.ID, 10)), wallet: author.address}
+	}
+
+	byCoOwner := comment(coOwner, "Draft note from a co-owner")
+	byOwner := comment(owner, "Draft note from the creator")
+
+	// Setelah dicabut, mantan co-owner tidak lagi melihat draft sehingga tidak bisa mengubah komentarnya
+	s.expect(t, testRequest{method: fiber.MethodDelete, path: projectPath(project.ID, "/owners/"+coOwner.address), wallet: owner.address}, fiber.StatusOK, nil)
+	s.expect(t, edit(coOwner, byCoOwner), fiber.StatusNotFound, nil)
+	s.expect(t, remove(coOwner, byCoOwner), fiber.StatusNotFound, nil)
+
+	s.expect(t, edit(owner, byOwner), fiber.StatusOK, nil)
+
+	// Komentar pada project yang di-soft delete ikut terkunci
+	s.expect(t, testRequest{method: fiber.MethodDelete, path: projectPath(project.ID, ""), wallet: owner.address}, fiber.StatusOK, nil)
+	s.expect(t, edit(owner, byOwner), fiber.StatusNotFound, nil)
+	s.expect(t, remove(owner, byOwner), fiber.StatusNotFound, nil)
+}
+
+func TestCommentTreeDepthAndHiddenThreads(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	reader := newTestWallet(2)
+	project := s.createLiveProject(t, owner, "Threaded")
+	path := projectPath(project.ID, "/comments")
+
+	root := s.postComment(t, project.ID, reader, nil, "Root")
+	reply := s.postComment(t, project.ID, reader, &root.ID, "Reply")
+	s.postComment(t, project.ID, reader, &reply.ID, "Nested reply")
+
+	// Dengan depth=2, balasan di level ketiga berada di luar batas sehingga tidak boleh ada replies_cursor
+	var tree model.CommentTreeResponse
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path + "?format=tree&depth=2"}, fiber.StatusOK, &tree)
+	if len(tree.Data) != 1 || len(tree.Data[0].Replies) != 1 {
+		t.Fatalf("tree = %+v, want root with one reply", tree.Data)
+	}
+	leaf := tree.Data[0].Replies[0]
+	if leaf.ID != reply.ID || leaf.ReplyCount != 1 || len(leaf.Replies) != 0 {
+		t.Fatalf("depth-1 node = %+v", leaf)
+	}
+	if leaf.RepliesCursor != "" {
+		t.Fatalf("replies_cursor = %q beyond depth=2, want none", leaf.RepliesCursor)
+	}
+
+	// Menyembunyikan balasan ikut menyembunyikan thread di bawahnya, baik di list flat maupun tree
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/moderation/comments/"+strconv.FormatUint(reply.ID, 10)+"/hide"),
+		wallet: owner.address}, fiber.StatusOK, nil)
+
+	var flat []model.Comment
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path}, fiber.StatusOK, &flat)
+	if len(flat) != 1 || flat[0].ID != root.ID {
+		t.Fatalf("flat comments = %+v, want only root", flat)
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path + "?format=tree"}, fiber.StatusOK, &tree)
+	if len(tree.Data) != 1 || len(tree.Data[0].Replies) != 0 || tree.Data[0].ReplyCount != 0 {
+		t.Fatalf("tree after hide = %+v, want root without replies", tree.Data)
+	}
+
+	// Creator project tetap melihat seluruh thread
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path, wallet: owner.address}, fiber.StatusOK, &flat)
+	if len(flat) != 3 {
+		t.Fatalf("creator comments = %+v, want root, reply and nested reply", flat)
+	}
+}
+
+// siweMessage menyusun pesan EIP-4361 minimal untuk wallet dan nonce yang diberikan
+func siweMessage(domain, address string, chainID int64, nonce string) string {
+	return domain + " wants you to sign in with your Ethereum account:\n" + address + "\n\n" +
+		"Sign in to Web3 Crowdfunding\n\n" +
+		"URI: http://" + domain + "/login\n" +
+		"Version: 1\n" +
+		"Chain ID: " + strconv.FormatInt(chainID, 10) + "\n" +
+		"Nonce: " + nonce + "\n" +
+		"Issued At: " + time.Now().UTC().Format(time.RFC3339)
+}
+
+func TestAuthVerify(t *testing.T) {
+	s := newTestServer(t)
+	user := newTestWallet(3)
+	nonce := func() string {
+		t.Helper()
+		var body model.NonceResponse
+		s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/auth/nonce"}, fiber.StatusOK, &body)
+		return body.Nonce
+	}
+	verify := func(message, signature string) testRequest {
+		return testRequest{method: fiber.MethodPost, path: "/api/v1/auth/verify", body: model.SIWEVerifyRequest{Message: message, Signature: signature}}
+	}
+
+	// Domain atau chain di luar AUTH_DOMAIN/AUTH_CHAIN_IDS, atau signature dari wallet lain, ditolak
+	n := nonce()
+	s.expect(t, verify(siweMessage("evil.example", user.address, 1, n), user.sign(siweMessage("evil.example", user.address, 1, n))), fiber.StatusUnauthorized, nil)
+	s.expect(t, verify(siweMessage(testDomain, user.address, 5, n), user.sign(siweMessage(testDomain, user.address, 5, n))), fiber.StatusUnauthorized, nil)
+	message := siweMessage(testDomain, user.address, 1, n)
+	s.expect(t, verify(message, newTestWallet(4).sign(message)), fiber.StatusUnauthorized, nil)
+
+	// Percobaan yang gagal tidak membakar nonce
+	var session model.SessionResponse
+	s.expect(t, verify(message, user.sign(message)), fiber.StatusOK, &session)
+	if session.WalletAddress != user.address || session.Token == "" {
+		t.Fatalf("session = %+v", session)
+	}
+	s.expect(t, verify(message, user.sign(message)), fiber.StatusUnauthorized, nil)
+
+	var me model.AuthMeResponse
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/auth/me",
+		headers: map[string]string{fiber.HeaderAuthorization: "Bearer " + session.Token}}, fiber.StatusOK, &me)
+	if me.WalletAddress != user.address {
+		t.Fatalf("me = %+v, want %s", me, user.address)
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/auth/me"}, fiber.StatusUnauthorized, nil)
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/auth/me",
+		headers: map[string]string{fiber.HeaderAuthorization: "Bearer " + session.Token + "x"}}, fiber.StatusUnauthorized, nil)
+}
+
+func TestDraftProjectIsPrivate(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	stranger := newTestWallet(2)
+	project := s.createProject(t, owner, "Secret draft")
+
+	// Draft dan semua sub-resource-nya tidak terlihat oleh wallet lain
+	for _, suffix := range []string{"", "/owners", "/investors", "/investments", "/investments/summary", "/comments", "/links"} {
+		s.expect(t, testRequest{method: fiber.MethodGet, path: projectPath(project.ID, suffix)}, fiber.StatusNotFound, nil)
+		s.expect(t, testRequest{method: fiber.MethodGet, path: projectPath(project.ID, suffix), wallet: stranger.address}, fiber.StatusNotFound, nil)
+		s.expect(t, testRequest{method: fiber.MethodGet, path: projectPath(project.ID, suffix), wallet: owner.address}, fiber.StatusOK, nil)
+	}
+	content := "Can I see this?"
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/comments"), wallet: stranger.address, body: model.CommentCreate{
+		AuthorWalletAddress: stranger.address, Content: content, Signature: stranger.sign(auth.CommentSigningMessage(project.ID, nil, content)),
+	}}, fiber.StatusNotFound, nil)
+
+	var list model.ProjectListResponse
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/projects"}, fiber.StatusOK, &list)
+	if len(list.Data) != 0 {
+		t.Fatalf("public listing = %+v, want no drafts", list.Data)
+	}
+
+	// Perubahan status dan data hanya untuk pemilik; wallet lain mendapat 403, tanpa login 401
+	for _, suffix := range []string{"/submit", "/archive"} {
+		s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, suffix)}, fiber.StatusUnauthorized, nil)
+		s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, suffix), wallet: stranger.address}, fiber.StatusForbidden, nil)
+	}
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/investments"), wallet: stranger.address,
+		body: map[string]interface{}{"wallet_address": testInvestor, "amount": "1"}}, fiber.StatusForbidden, nil)
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/links"), wallet: stranger.address,
+		body: model.ExternalLinkCreate{Name: "Website", URL: "https://example.com"}}, fiber.StatusForbidden, nil)
+
+	// Archived adalah status akhir
+	var archived model.Project
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/archive"), wallet: owner.address}, fiber.StatusOK, &archived)
+	if archived.Status != model.ProjectStatusArchived {
+		t.Fatalf("status after archive = %s, want archived", archived.Status)
+	}
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(project.ID, "/submit"), wallet: owner.address}, fiber.StatusConflict, nil)
+}
+
+func TestInvestorCountSortHasNoCursor(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	popular := s.createLiveProject(t, owner, "Popular")
+	s.createLiveProject(t, owner, "Quiet")
+	s.expect(t, testRequest{method: fiber.MethodPost, path: projectPath(popular.ID, "/investors"), wallet: owner.address,
+		body: model.AddInvestorRequest{WalletAddress: testInvestor}}, fiber.StatusOK, nil)
+
+	// Jumlah investor bisa berubah di antara halaman, jadi sort ini hanya mengembalikan halaman teratas
+	var list struct {
+		Data       []model.Project `json:"data"`
+		NextCursor string          `json:"next_cursor"`
+		Total      int64           `json:"total"`
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/projects?sort=-investor_count&limit=1"}, fiber.StatusOK, &list)
+	if len(list.Data) != 1 || list.Data[0].ID != popular.ID || list.NextCursor != "" || list.Total != 2 {
+		t.Fatalf("investor_count page = %+v", list)
+	}
+
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/projects?sort=created_at&limit=1"}, fiber.StatusOK, &list)
+	if list.NextCursor == "" {
+		t.Fatal("created_at sort returned no next_cursor")
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/projects?sort=-investor_count&limit=1&cursor=" + list.NextCursor}, fiber.StatusBadRequest, nil)
+}
+
+func TestExternalLinks(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	project := s.createLiveProject(t, owner, "Linked")
+	path := projectPath(project.ID, "/links")
+
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address,
+		body: model.ExternalLinkCreate{Name: "Files", URL: "ftp://example.com/game.zip"}}, fiber.StatusBadRequest, nil)
+
+	var site, discord model.ExternalLink
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address,
+		body: model.ExternalLinkCreate{Name: "Website", Type: "website", URL: "https://example.com"}}, fiber.StatusCreated, &site)
+	s.expect(t, testRequest{method: fiber.MethodPost, path: path, wallet: owner.address,
+		body: model.ExternalLinkCreate{Name: "Discord", Type: "discord", URL: "https://discord.gg/example"}}, fiber.StatusCreated, &discord)
+	if site.Position != 0 || discord.Position != 1 {
+		t.Fatalf("positions = %d, %d, want 0, 1", site.Position, discord.Position)
+	}
+
+	linkPath := path + "/" + strconv.FormatUint(site.ID, 10)
+	s.expect(t, testRequest{method: fiber.MethodPut, path: linkPath, wallet: newTestWallet(2).address,
+		body: map[string]interface{}{"name": "Hijacked"}}, fiber.StatusForbidden, nil)
+	var updated model.ExternalLink
+	s.expect(t, testRequest{method: fiber.MethodPut, path: linkPath, wallet: owner.address,
+		body: map[string]interface{}{"position": 5}}, fiber.StatusOK, &updated)
+	if updated.Name != "Website" || updated.Position != 5 {
+		t.Fatalf("updated link = %+v", updated)
+	}
+
+	var links []model.ExternalLink
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path}, fiber.StatusOK, &links)
+	if len(links) != 2 || links[0].ID != discord.ID || links[1].ID != site.ID {
+		t.Fatalf("links = %+v, want discord before website", links)
+	}
+
+	s.expect(t, testRequest{method: fiber.MethodDelete, path: linkPath, wallet: owner.address}, fiber.StatusOK, nil)
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path}, fiber.StatusOK, &links)
+	if len(links) != 1 {
+		t.Fatalf("links after delete = %+v, want 1", links)
+	}
+}
+
+func TestCommentModerationQueue(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	author := newTestWallet(2)
+	reporter := newTestWallet(3)
+	project := s.createLiveProject(t, owner, "Moderated")
+	comment := s.postComment(t, project.ID, author, nil, "Buy cheap tokens at example.com")
+	commentPath := projectPath(project.ID, "/comments/"+strconv.FormatUint(comment.ID, 10))
+
+	s.expect(t, testRequest{method: fiber.MethodPost, path: commentPath + "/reports", wallet: reporter.address,
+		body: model.CommentReportRequest{Reason: "Spam"}}, fiber.StatusCreated, nil)
+
+	// Antrian per project untuk creator dan admin, antrian global hanya untuk admin
+	queuePath := projectPath(project.ID, "/moderation/comments")
+	s.expect(t, testRequest{method: fiber.MethodGet, path: queuePath, wallet: author.address}, fiber.StatusForbidden, nil)
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/moderation/comments", wallet: owner.address}, fiber.StatusForbidden, nil)
+
+	var queue model.ModerationQueueResponse
+	s.expect(t, testRequest{method: fiber.MethodGet, path: queuePath, wallet: owner.address}, fiber.StatusOK, &queue)
+	if queue.Total != 1 || len(queue.Data) != 1 || queue.Data[0].Comment.ID != comment.ID || queue.Data[0].ReportCount != 1 {
+		t.Fatalf("project queue = %+v", queue)
+	}
+	s.expect(t, testRequest{method: fiber.MethodGet, path: "/api/v1/moderation/comments", wallet: s.admin.address}, fiber.StatusOK, &queue)
+	if queue.Total != 1 {
+		t.Fatalf("global queue total = %d, want 1", queue.Total)
+	}
+
+	// Komentar yang disembunyikan keluar dari antrian dan tidak lagi bisa dilaporkan
+	s.expect(t, testRequest{method: fiber.MethodPost, path: queuePath + "/" + strconv.FormatUint(comment.ID, 10) + "/hide", wallet: author.address}, fiber.StatusForbidden, nil)
+	s.expect(t, testRequest{method: fiber.MethodPost, path: queuePath + "/" + strconv.FormatUint(comment.ID, 10) + "/hide", wallet: s.admin.address}, fiber.StatusOK, nil)
+	s.expect(t, testRequest{method: fiber.MethodGet, path: queuePath, wallet: owner.address}, fiber.StatusOK, &queue)
+	if queue.Total != 0 {
+		t.Fatalf("queue after hide = %+v, want empty", queue)
+	}
+	s.expect(t, testRequest{method: fiber.MethodPost, path: commentPath + "/reports", wallet: newTestWallet(4).address,
+		body: model.CommentReportRequest{Reason: "Still spam"}}, fiber.StatusNotFound, nil)
+}
+
+func TestCommentTreeCursors(t *testing.T) {
+	s := newTestServer(t)
+	owner := newTestWallet(1)
+	reader := newTestWallet(2)
+	project := s.createLiveProject(t, owner, "Busy thread")
+	path := projectPath(project.ID, "/comments?format=tree")
+
+	var roots []model.Comment
+	for _, content := range []string{"First", "Second", "Third"} {
+		roots = append(roots, s.postComment(t, project.ID, reader, nil, content))
+	}
+	var replies []model.Comment
+	for _, content := range []string{"Reply 1", "Reply 2", "Reply 3"} {
+		replies = append(replies, s.postComment(t, project.ID, reader, &roots[2].ID, content))
+	}
+
+	// Top-level terbaru lebih dulu; next_cursor melanjutkan ke komentar yang lebih lama
+	var page model.CommentTreeResponse
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path + "&limit=2&replies=2"}, fiber.StatusOK, &page)
+	if len(page.Data) != 2 || page.Data[0].ID != roots[2].ID || page.Data[1].ID != roots[1].ID || page.NextCursor == "" {
+		t.Fatalf("first page = %+v, next_cursor = %q", page.Data, page.NextCursor)
+	}
+	thread := page.Data[0]
+	if thread.ReplyCount != 3 || len(thread.Replies) != 2 || thread.Replies[0].ID != replies[0].ID || thread.RepliesCursor == "" {
+		t.Fatalf("thread = %+v", thread)
+	}
+
+	var next model.CommentTreeResponse
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path + "&limit=2&replies=2&cursor=" + page.NextCursor}, fiber.StatusOK, &next)
+	if len(next.Data) != 1 || next.Data[0].ID != roots[0].ID || next.NextCursor != "" {
+		t.Fatalf("second page = %+v, next_cursor = %q", next.Data, next.NextCursor)
+	}
+
+	// replies_cursor memuat balasan yang tersisa, terlama lebih dulu
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path + "&limit=2&cursor=" + thread.RepliesCursor}, fiber.StatusOK, &next)
+	if len(next.Data) != 1 || next.Data[0].ID != replies[2].ID || next.Data[0].Depth != 1 {
+		t.Fatalf("replies page = %+v", next.Data)
+	}
+
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path + "&cursor=not-a-cursor"}, fiber.StatusBadRequest, nil)
+	s.expect(t, testRequest{method: fiber.MethodGet, path: path + "&depth=6"}, fiber.StatusBadRequest, nil)
+}
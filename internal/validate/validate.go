@@ -0,0 +1,235 @@
+// Package validate menjalankan validasi deklaratif berdasarkan tag struct `validate`.
+// Semua pelanggaran dikumpulkan sekaligus (bukan berhenti di yang pertama) sehingga client
+// bisa memperbaiki seluruh field dalam satu kali kirim.
+//
+// Aturan yang didukung (dipisah koma, dijalankan berurutan):
+//
+//	required   wajib diisi: string tidak kosong/spasi saja, pointer tidak nil, slice tidak kosong
+//	nonblank   jika ada, string tidak boleh kosong/spasi saja (untuk field pointer di PATCH)
+//	omitempty  lewati aturan berikutnya jika nilai kosong
+//	max=N      string paling banyak N karakter, slice paling banyak N elemen
+//	min=N      angka minimal N, string minimal N karakter
+//	url        URL absolut http/https dengan host, tanpa spasi dan kredensial
+//	email      alamat email tunggal
+//	wallet     address Ethereum (0x + 40 hex)
+//	rfc3339    timestamp RFC 3339
+//	oneof=a b  salah satu nilai yang dipisah spasi
+//
+// Field pointer yang nil hanya diperiksa oleh aturan required. Struct, pointer ke struct dan
+// slice struct divalidasi secara rekursif dengan nama field seperti "links[0].url".
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kevinchr/web3-crowdfunding-api/internal/apperror"
+)
+
+var walletPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Struct memvalidasi v (struct atau pointer ke struct) dan mengembalikan semua pelanggaran
+func Struct(v interface{}) []apperror.FieldError {
+	var errs []apperror.FieldError
+	walk(reflect.ValueOf(v), "", &errs)
+	return errs
+}
+
+// walk memvalidasi setiap field struct dan turun ke struct/slice di dalamnya
+func walk(v reflect.Value, prefix string, errs *[]apperror.FieldError) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name := fieldName(sf)
+			if name == "" {
+				continue
+			}
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			fv := v.Field(i)
+			if tag := sf.Tag.Get("validate"); tag != "" {
+				checkField(fv, name, tag, errs)
+			}
+			walk(fv, name, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), prefix+"["+strconv.Itoa(i)+"]", errs)
+		}
+	}
+}
+
+// fieldName mengambil nama field dari tag json; "" jika field diabaikan JSON
+func fieldName(sf reflect.StructField) string {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return sf.Name
+}
+
+// checkField menjalankan aturan tag pada satu field; berhenti di pelanggaran pertama field itu
+func checkField(v reflect.Value, name, tag string, errs *[]apperror.FieldError) {
+	rules := strings.Split(tag, ",")
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			for _, rule := range rules {
+				if rule == "required" {
+					*errs = append(*errs, apperror.FieldError{Field: name, Message: name + " is required"})
+				}
+			}
+			return
+		}
+		v = v.Elem()
+	}
+
+	for _, rule := range rules {
+		key, arg, _ := strings.Cut(rule, "=")
+		if key == "omitempty" {
+			if isEmpty(v) {
+				return
+			}
+			continue
+		}
+		if msg := checkRule(v, name, key, arg); msg != "" {
+			*errs = append(*errs, apperror.FieldError{Field: name, Message: msg})
+			return
+		}
+	}
+}
+
+// checkRule mengembalikan pesan pelanggaran, atau "" jika nilai lolos aturan
+func checkRule(v reflect.Value, name, key, arg string) string {
+	switch key {
+	case "required", "nonblank":
+		if isEmpty(v) {
+			if key == "nonblank" {
+				return name + " must not be blank"
+			}
+			return name + " is required"
+		}
+	case "max":
+		n := mustAtoi(arg)
+		switch v.Kind() {
+		case reflect.String:
+			if utf8.RuneCountInString(v.String()) > n {
+				return fmt.Sprintf("%s must be at most %d characters", name, n)
+			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			if v.Len() > n {
+				return fmt.Sprintf("%s must have at most %d items", name, n)
+			}
+		}
+	case "min":
+		n := mustAtoi(arg)
+		switch v.Kind() {
+		case reflect.String:
+			if utf8.RuneCountInString(v.String()) < n {
+				return fmt.Sprintf("%s must be at least %d characters", name, n)
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.Int() < int64(n) {
+				return fmt.Sprintf("%s must be at least %d", name, n)
+			}
+		}
+	case "url":
+		if msg := checkURL(v.String()); msg != "" {
+			return name + " " + msg
+		}
+	case "email":
+		addr, err := mail.ParseAddress(v.String())
+		if err != nil || addr.Address != v.String() {
+			return name + " must be a valid email address"
+		}
+	case "wallet":
+		if !walletPattern.MatchString(v.String()) {
+			return name + " must be a 0x-prefixed 40 hex character wallet address"
+		}
+	case "rfc3339":
+		if _, err := time.Parse(time.RFC3339, v.String()); err != nil {
+			return name + " must be an RFC 3339 timestamp"
+		}
+	case "oneof":
+		options := strings.Fields(arg)
+		for _, option := range options {
+			if v.String() == option {
+				return ""
+			}
+		}
+		return name + " must be one of: " + strings.Join(options, ", ")
+	default:
+		panic("validate: unknown rule " + key)
+	}
+	return ""
+}
+
+// checkURL hanya menerima URL absolut http(s). Skema lain seperti javascript: atau data:
+// ditolak karena URL ditampilkan apa adanya di frontend.
+func checkURL(raw string) string {
+	if strings.ContainsAny(raw, " \t\r\n") {
+		return "must not contain whitespace"
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "is not a valid URL"
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "must use http or https"
+	}
+	if parsed.Host == "" || parsed.Hostname() == "" {
+		return "must include a host"
+	}
+	if parsed.User != nil {
+		return "must not contain credentials"
+	}
+	return ""
+}
+
+// isEmpty mengecek nilai kosong; string yang hanya berisi spasi dianggap kosong
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+func mustAtoi(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		panic("validate: invalid rule argument " + strconv.Quote(s))
+	}
+	return n
+}